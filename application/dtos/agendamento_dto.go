@@ -1,57 +1,330 @@
 package dtos
 
-// ItemAgendamentoCreateDTO representa um item de serviço para criação de um agendamento
+// ItemAgendamentoCreateDTO representa um item de serviço para criação de um agendamento.
+// O preço é calculado pelo servidor; preco_previsto é opcional e, se informado, precisa coincidir com ele
 type ItemAgendamentoCreateDTO struct {
 	ServicoID     string  `json:"servico_id" binding:"required"`
-	PrecoPrevisto float64 `json:"preco_previsto" binding:"required,min=0"`
+	PrecoPrevisto float64 `json:"preco_previsto" binding:"omitempty,min=0"`
 }
 
-// AgendamentoCreateDTO representa dados para criação de um novo agendamento
+// PetAgendamentoCreateDTO representa um dos pets do agendamento e os serviços pedidos para ele
+type PetAgendamentoCreateDTO struct {
+	PetID string                     `json:"pet_id" binding:"required"`
+	Itens []ItemAgendamentoCreateDTO `json:"itens" binding:"required,min=1,dive"`
+}
+
+// AgendamentoCreateDTO representa dados para criação de um novo agendamento.
+// Um pet é informado por pet_id e itens; vários pets do mesmo dono, pela lista pets
 type AgendamentoCreateDTO struct {
 	DonoID        string                     `json:"dono_id" binding:"required"`
-	PetID         string                     `json:"pet_id" binding:"required"`
+	PetID         string                     `json:"pet_id" binding:"required_without=Pets"`
 	PetshopID     string                     `json:"petshop_id" binding:"required"`
 	DataAgendada  string                     `json:"data_agendada" binding:"required"` // Formato ISO8601: 2006-01-02T15:04:05Z07:00
 	Observacoes   string                     `json:"observacoes"`
-	TotalPrevisto float64                    `json:"total_previsto" binding:"required,min=0"`
-	Itens         []ItemAgendamentoCreateDTO `json:"itens" binding:"required,dive"`
+	TotalPrevisto float64                    `json:"total_previsto" binding:"omitempty,min=0"` // Opcional; conferido com o total calculado
+	Itens         []ItemAgendamentoCreateDTO `json:"itens" binding:"required_without=Pets,dive"`
+	Pets          []PetAgendamentoCreateDTO  `json:"pets" binding:"omitempty,max=10,dive"` // Substitui pet_id e itens
+
+	// Funcionário desejado; se omitido e o petshop tiver funcionários, o menos ocupado no dia é atribuído
+	FuncionarioID string `json:"funcionario_id"`
+}
+
+// ClienteAvulsoDTO representa um cliente sem conta e o seu pet, informados pelo petshop
+type ClienteAvulsoDTO struct {
+	Nome       string `json:"nome" binding:"required,max=100"`
+	Email      string `json:"email" binding:"omitempty,email,max=255"`
+	Telefone   string `json:"telefone" binding:"required_without=Email,max=20"`
+	NomePet    string `json:"nome_pet" binding:"required,max=100"`
+	EspeciePet string `json:"especie_pet" binding:"required,max=50"`
+	RacaPet    string `json:"raca_pet" binding:"max=50"`
+}
+
+// ClienteAvulsoVincularDTO representa a confirmação, pelo petshop, de que o cliente avulso é o dono cadastrado
+type ClienteAvulsoVincularDTO struct {
+	DonoID string `json:"dono_id" binding:"required"`
+}
+
+// ClienteAvulsoVinculoDTO representa o resultado do vínculo de um cliente avulso a um dono cadastrado
+type ClienteAvulsoVinculoDTO struct {
+	ClienteAvulsoID string `json:"cliente_avulso_id"`
+	DonoID          string `json:"dono_id"`
+	PetID           string `json:"pet_id"`
+	Agendamentos    int    `json:"agendamentos"` // Agendamentos que passaram a ser do dono
+	VinculadoEm     string `json:"vinculado_em"`
+}
+
+// AgendamentoPetshopCreateDTO representa um agendamento feito pelo petshop por telefone ou no balcão,
+// para um dono cadastrado (dono_id e pet_id) ou para um cliente sem conta (cliente)
+type AgendamentoPetshopCreateDTO struct {
+	Canal         string                     `json:"canal" binding:"required,oneof=telefone balcao"`
+	DonoID        string                     `json:"dono_id"`
+	PetID         string                     `json:"pet_id"`
+	Cliente       *ClienteAvulsoDTO          `json:"cliente"`
+	DataAgendada  string                     `json:"data_agendada" binding:"required"` // Formato ISO8601: 2006-01-02T15:04:05Z07:00
+	Observacoes   string                     `json:"observacoes"`
+	TotalPrevisto float64                    `json:"total_previsto" binding:"omitempty,min=0"` // Opcional; conferido com o total calculado
+	Itens         []ItemAgendamentoCreateDTO `json:"itens" binding:"required_without=Pets,dive"`
+	Pets          []PetAgendamentoCreateDTO  `json:"pets" binding:"omitempty,max=10,dive"` // Apenas para donos cadastrados
+	FuncionarioID string                     `json:"funcionario_id"`
 }
 
 // ItemAgendamentoResponseDTO representa um item de serviço na resposta de um agendamento
 type ItemAgendamentoResponseDTO struct {
-	ID            string  `json:"id"`
-	ServicoID     string  `json:"servico_id"`
-	NomeServico   string  `json:"nome_servico"`
-	PrecoPrevisto float64 `json:"preco_previsto"`
+	ID             string  `json:"id"`
+	ServicoID      string  `json:"servico_id"`
+	NomeServico    string  `json:"nome_servico"`
+	PetID          string  `json:"pet_id,omitempty"`
+	NomePet        string  `json:"nome_pet,omitempty"`
+	PrecoPrevisto  float64 `json:"preco_previsto"`
+	DuracaoMinutos int     `json:"duracao_minutos"`
+
+	// Execução registrada pelo petshop durante o atendimento (previsto, executado ou pulado)
+	Execucao       string   `json:"execucao"`
+	Adicionado     bool     `json:"adicionado"` // Incluído no balcão
+	PrecoFinal     *float64 `json:"preco_final,omitempty"`
+	MotivoExecucao string   `json:"motivo_execucao,omitempty"`
+}
+
+// PetAgendamentoResponseDTO representa um dos pets do agendamento, com os seus serviços e o seu total
+type PetAgendamentoResponseDTO struct {
+	PetID   string                       `json:"pet_id,omitempty"`
+	NomePet string                       `json:"nome_pet"`
+	Itens   []ItemAgendamentoResponseDTO `json:"itens"`
+	Total   float64                      `json:"total"`
+	// Total cobrado: sem os itens pulados e com os preços finais
+	TotalFinal float64 `json:"total_final"`
 }
 
 // AgendamentoResponseDTO representa a estrutura de dados de resposta para um agendamento
 type AgendamentoResponseDTO struct {
-	ID            string                       `json:"id"`
-	DonoID        string                       `json:"dono_id"`
-	NomeDono      string                       `json:"nome_dono"`
-	PetID         string                       `json:"pet_id"`
-	NomePet       string                       `json:"nome_pet"`
-	PetshopID     string                       `json:"petshop_id"`
-	NomePetshop   string                       `json:"nome_petshop"`
-	DataAgendada  string                       `json:"data_agendada"`
-	Status        string                       `json:"status"`
-	Observacoes   string                       `json:"observacoes"`
-	TotalPrevisto float64                      `json:"total_previsto"`
-	Itens         []ItemAgendamentoResponseDTO `json:"itens"`
-	CreatedAt     string                       `json:"created_at"`
-	UpdatedAt     string                       `json:"updated_at"`
+	ID               string                       `json:"id"`
+	DonoID           string                       `json:"dono_id"`
+	NomeDono         string                       `json:"nome_dono"`
+	PetID            string                       `json:"pet_id"`
+	NomePet          string                       `json:"nome_pet"`
+	PetshopID        string                       `json:"petshop_id"`
+	NomePetshop      string                       `json:"nome_petshop"`
+	DataAgendada     string                       `json:"data_agendada"`
+	DataFim          string                       `json:"data_fim"` // Término previsto, calculado pela duração dos serviços
+	Status           string                       `json:"status"`
+	Observacoes      string                       `json:"observacoes"`
+	TotalPrevisto    float64                      `json:"total_previsto"`
+	TotalFinal       float64                      `json:"total_final"` // Sem os itens pulados e com os preços finais
+	Itens            []ItemAgendamentoResponseDTO `json:"itens"`
+	Pets             []PetAgendamentoResponseDTO  `json:"pets"` // Serviços e total de cada pet
+	Precificacao     PrecificacaoDTO              `json:"precificacao"`
+	RequerRemarcacao bool                         `json:"requer_remarcacao"` // Atingido por um fechamento do petshop
+	MotivoRemarcacao string                       `json:"motivo_remarcacao,omitempty"`
+	SerieID          string                       `json:"serie_id,omitempty"` // Série recorrente que gerou o agendamento
+	Cancelamento     *CancelamentoDTO             `json:"cancelamento,omitempty"`
+	CreatedAt        string                       `json:"created_at"`
+	UpdatedAt        string                       `json:"updated_at"`
+
+	// Donos com muitas faltas precisam confirmar presença antes da confirmação do petshop
+	ExigeConfirmacaoPresenca bool                   `json:"exige_confirmacao_presenca"`
+	PresencaConfirmadaEm     string                 `json:"presenca_confirmada_em,omitempty"`
+	ConfiabilidadeDono       *ConfiabilidadeDonoDTO `json:"confiabilidade_dono,omitempty"`
+
+	Remarcacoes   int    `json:"remarcacoes"` // Quantidade de vezes que o agendamento foi remarcado
+	FuncionarioID string `json:"funcionario_id,omitempty"`
+	Versao        int    `json:"versao"` // Também enviada no cabeçalho ETag; use em If-Match ao atualizar
+
+	// Canal do agendamento (app, telefone ou balcao); agendamentos de clientes sem conta não têm dono_id nem pet_id
+	Origem          string `json:"origem"`
+	ClienteAvulsoID string `json:"cliente_avulso_id,omitempty"`
+
+	// Chegada e saída registradas pelo petshop; a duração real é comparável à prevista pelos serviços
+	CheckInEm              string `json:"check_in_em,omitempty"`
+	CheckOutEm             string `json:"check_out_em,omitempty"`
+	DuracaoPrevistaMinutos int    `json:"duracao_prevista_minutos"`
+	DuracaoRealMinutos     *int   `json:"duracao_real_minutos,omitempty"`
+}
+
+// ItemPrecificacaoDTO representa o preço calculado pelo servidor para um serviço
+type ItemPrecificacaoDTO struct {
+	ServicoID   string  `json:"servico_id"`
+	NomeServico string  `json:"nome_servico"`
+	Preco       float64 `json:"preco"`
+}
+
+// PrecificacaoDTO detalha como o total previsto foi calculado a partir dos preços dos serviços
+type PrecificacaoDTO struct {
+	Itens []ItemPrecificacaoDTO `json:"itens"`
+	Total float64               `json:"total"`
+}
+
+// CancelamentoDTO representa os dados de cancelamento de um agendamento, incluindo a taxa devida
+// pelo dono quando o cancelamento ocorre fora do prazo definido pelo petshop
+type CancelamentoDTO struct {
+	CanceladoEm string  `json:"cancelado_em"`
+	NoPrazo     bool    `json:"no_prazo"`
+	Taxa        float64 `json:"taxa"`
+}
+
+// ConfiabilidadeDonoDTO representa o histórico de não comparecimentos do dono em todos os petshops
+type ConfiabilidadeDonoDTO struct {
+	NaoComparecimentos      int    `json:"nao_comparecimentos"`
+	UltimoNaoComparecimento string `json:"ultimo_nao_comparecimento,omitempty"`
 }
 
 // AgendamentoUpdateStatusDTO representa dados para atualização do status de um agendamento
 type AgendamentoUpdateStatusDTO struct {
-	Status string `json:"status" binding:"required,oneof=pendente confirmado cancelado concluido"`
+	Status string `json:"status" binding:"required,oneof=pendente confirmado cancelado concluido nao_compareceu"`
+	Motivo string `json:"motivo" binding:"max=500"` // Opcional, registrado no histórico (ex.: motivo do cancelamento)
+
+	// Opcional ao concluir: preço final cobrado por item. Itens omitidos mantêm o preço previsto
+	PrecosFinais []PrecoFinalItemDTO `json:"precos_finais" binding:"omitempty,dive"`
+}
+
+// ItemExecucaoUpdateDTO representa a execução de um serviço registrada pelo petshop durante o atendimento
+type ItemExecucaoUpdateDTO struct {
+	Execucao   string   `json:"execucao" binding:"required,oneof=previsto executado pulado"`
+	PrecoFinal *float64 `json:"preco_final" binding:"omitempty,min=0"` // Opcional; sem ele vale o preço previsto
+	Motivo     string   `json:"motivo" binding:"max=500"`              // Ex.: por que o serviço foi pulado
+}
+
+// ItemAdicionadoCreateDTO representa um serviço incluído no balcão durante o atendimento, já executado
+type ItemAdicionadoCreateDTO struct {
+	ServicoID  string   `json:"servico_id" binding:"required"`
+	PetID      string   `json:"pet_id"`                                // Obrigatório em agendamentos com vários pets
+	PrecoFinal *float64 `json:"preco_final" binding:"omitempty,min=0"` // Opcional; padrão: preço atual do serviço
+	Motivo     string   `json:"motivo" binding:"max=500"`
+}
+
+// PrecoFinalItemDTO representa o preço efetivamente cobrado por um item ao concluir o agendamento
+type PrecoFinalItemDTO struct {
+	ItemID     string  `json:"item_id" binding:"required"`
+	PrecoFinal float64 `json:"preco_final" binding:"min=0"`
+}
+
+// AgendamentoFiltroDTO representa os filtros, a ordenação e a paginação das listagens de agendamentos,
+// recebidos pela query string
+type AgendamentoFiltroDTO struct {
+	De        string `form:"de"`         // AAAA-MM-DD, inclusiva
+	Ate       string `form:"ate"`        // AAAA-MM-DD, inclusiva
+	Status    string `form:"status"`     // Um ou mais status separados por vírgula
+	PetID     string `form:"pet_id"`     // Apenas agendamentos do pet
+	ServicoID string `form:"servico_id"` // Apenas agendamentos que incluem o serviço
+	Ordenar   string `form:"ordenar"`    // data_agendada (padrão), created_at, total_previsto ou status
+	Ordem     string `form:"ordem"`      // asc ou desc (padrão)
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+// AgendamentoPaginaDTO representa uma página de agendamentos e o total de resultados do filtro
+type AgendamentoPaginaDTO struct {
+	Agendamentos []AgendamentoResponseDTO `json:"agendamentos"`
+	Total        int64                    `json:"total"`
+	Page         int                      `json:"page"`
+	Limit        int                      `json:"limit"`
+	TotalPaginas int                      `json:"total_paginas"`
+}
+
+// AgendaItemDTO representa um agendamento na agenda do petshop, com os dados usados pela recepção
+type AgendaItemDTO struct {
+	ID               string   `json:"id"`
+	Inicio           string   `json:"inicio"`
+	Fim              string   `json:"fim"` // Término previsto
+	Status           string   `json:"status"`
+	PetID            string   `json:"pet_id"`
+	NomePet          string   `json:"nome_pet"` // Com vários pets, os nomes separados por vírgula
+	EspeciePet       string   `json:"especie_pet"`
+	DonoID           string   `json:"dono_id"`
+	NomeDono         string   `json:"nome_dono"`
+	Servicos         []string `json:"servicos"`
+	Observacoes      string   `json:"observacoes,omitempty"`
+	RequerRemarcacao bool     `json:"requer_remarcacao,omitempty"`
+
+	// Preenchidos em agendamentos de clientes sem conta, feitos pelo petshop
+	ClienteAvulsoID string `json:"cliente_avulso_id,omitempty"`
+	TelefoneCliente string `json:"telefone_cliente,omitempty"`
+}
+
+// AgendaGrupoDTO reúne os agendamentos de um dia atribuídos ao mesmo funcionário.
+// O grupo sem funcionário reúne os agendamentos ainda não atribuídos
+type AgendaGrupoDTO struct {
+	FuncionarioID   string          `json:"funcionario_id,omitempty"`
+	NomeFuncionario string          `json:"nome_funcionario,omitempty"`
+	Agendamentos    []AgendaItemDTO `json:"agendamentos"`
+}
+
+// AgendaDiaDTO representa os agendamentos de um dia da agenda, agrupados por funcionário
+type AgendaDiaDTO struct {
+	Data   string           `json:"data"` // AAAA-MM-DD no fuso do petshop
+	Total  int              `json:"total"`
+	Grupos []AgendaGrupoDTO `json:"grupos"`
+}
+
+// AgendaPetshopDTO representa a agenda do petshop em um período, dia a dia
+type AgendaPetshopDTO struct {
+	PetshopID string         `json:"petshop_id"`
+	De        string         `json:"de"`
+	Ate       string         `json:"ate"`
+	Dias      []AgendaDiaDTO `json:"dias"`
+}
+
+// HistoricoAgendamentoResponseDTO representa uma mudança de status no histórico de um agendamento
+type HistoricoAgendamentoResponseDTO struct {
+	ID             string `json:"id"`
+	StatusAnterior string `json:"status_anterior"`
+	StatusNovo     string `json:"status_novo"`
+	AtorID         string `json:"ator_id"`
+	AtorTipo       string `json:"ator_tipo"`
+	Motivo         string `json:"motivo,omitempty"`
+	CreatedAt      string `json:"created_at"`
+
+	// Presentes apenas em remarcações
+	DataAnterior string `json:"data_anterior,omitempty"`
+	DataNova     string `json:"data_nova,omitempty"`
+}
+
+// AgendamentoRemarcarDTO representa dados para mover um agendamento para outra data, mantendo serviços e preços
+type AgendamentoRemarcarDTO struct {
+	DataAgendada string `json:"data_agendada" binding:"required"` // Formato ISO8601: 2006-01-02T15:04:05Z07:00
+	Motivo       string `json:"motivo" binding:"max=500"`
 }
 
 // AgendamentoUpdateDTO representa dados para atualização de um agendamento existente
 type AgendamentoUpdateDTO struct {
-	DataAgendada  string                     `json:"data_agendada" binding:"required"` // Formato ISO8601: 2006-01-02T15:04:05Z07:00
+	DataAgendada  string                     `json:"data_agendada"` // Opcional; se informada (ISO8601), deve ser a data atual. Use /remarcar para mudá-la
 	Observacoes   string                     `json:"observacoes"`
-	TotalPrevisto float64                    `json:"total_previsto" binding:"required,min=0"`
-	Itens         []ItemAgendamentoCreateDTO `json:"itens" binding:"required,dive"`
+	TotalPrevisto float64                    `json:"total_previsto" binding:"omitempty,min=0"` // Opcional; conferido com o total calculado
+	Itens         []ItemAgendamentoCreateDTO `json:"itens" binding:"required_without=Pets,dive"`
+	Pets          []PetAgendamentoCreateDTO  `json:"pets" binding:"omitempty,max=10,dive"` // Obrigatório em agendamentos com vários pets
+}
+
+// SerieAgendamentoCreateDTO representa dados para criação de uma série de agendamentos recorrentes.
+// data_agendada define a primeira ocorrência; a série termina em data_limite ou após quantidade ocorrências
+type SerieAgendamentoCreateDTO struct {
+	AgendamentoCreateDTO
+	Frequencia string `json:"frequencia" binding:"required,oneof=semanal quinzenal mensal"`
+	DataLimite string `json:"data_limite" binding:"required_without=Quantidade"` // Formato AAAA-MM-DD, inclusiva
+	Quantidade int    `json:"quantidade" binding:"omitempty,min=1,max=52"`
+}
+
+// SerieAgendamentoCancelDTO representa dados para cancelamento de uma série inteira
+type SerieAgendamentoCancelDTO struct {
+	Motivo string `json:"motivo" binding:"max=500"`
+}
+
+// OcorrenciaFalhaDTO descreve uma ocorrência da série que não pôde ser agendada
+type OcorrenciaFalhaDTO struct {
+	DataAgendada string `json:"data_agendada"`
+	Motivo       string `json:"motivo"`
+}
+
+// SerieAgendamentoResponseDTO representa a estrutura de dados de resposta para uma série de agendamentos
+type SerieAgendamentoResponseDTO struct {
+	ID           string                   `json:"id"`
+	DonoID       string                   `json:"dono_id"`
+	PetID        string                   `json:"pet_id"`
+	PetshopID    string                   `json:"petshop_id"`
+	Frequencia   string                   `json:"frequencia"`
+	DataInicio   string                   `json:"data_inicio"`
+	DataLimite   string                   `json:"data_limite,omitempty"`
+	Quantidade   int                      `json:"quantidade,omitempty"`
+	Cancelada    bool                     `json:"cancelada"`
+	Agendamentos []AgendamentoResponseDTO `json:"agendamentos"`
+	Falhas       []OcorrenciaFalhaDTO     `json:"falhas,omitempty"` // Apenas na criação
+	CreatedAt    string                   `json:"created_at"`
 }
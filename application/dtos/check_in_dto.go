@@ -0,0 +1,15 @@
+package dtos
+
+// TicketAgendamentoDTO representa o ticket de check-in de um agendamento confirmado. O conteúdo de ticket
+// é o que o aplicativo codifica no QR code apresentado pelo dono na chegada
+type TicketAgendamentoDTO struct {
+	AgendamentoID string `json:"agendamento_id"`
+	Ticket        string `json:"ticket"`
+	DataAgendada  string `json:"data_agendada"`
+	NomePetshop   string `json:"nome_petshop"`
+}
+
+// CheckInDTO representa o ticket lido do QR code pelo petshop
+type CheckInDTO struct {
+	Ticket string `json:"ticket" binding:"required,max=200"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+// FechamentoCreateDTO representa dados para cadastro de um fechamento do petshop
+type FechamentoCreateDTO struct {
+	// Para fechamentos de dia inteiro use AAAA-MM-DD (data_fim inclusiva);
+	// para fechamentos parciais use ISO8601: 2006-01-02T15:04:05Z07:00 (data_fim exclusiva)
+	DataInicio      string `json:"data_inicio" binding:"required"`
+	DataFim         string `json:"data_fim" binding:"required"`
+	DiaInteiro      bool   `json:"dia_inteiro"`
+	RecorrenteAnual bool   `json:"recorrente_anual"` // Repete todos os anos (apenas dia inteiro)
+	Motivo          string `json:"motivo" binding:"required,max=200"`
+}
+
+// FechamentoResponseDTO representa a estrutura de dados de resposta para um fechamento do petshop
+type FechamentoResponseDTO struct {
+	ID                   string   `json:"id"`
+	PetshopID            string   `json:"petshop_id"`
+	Inicio               string   `json:"inicio"`
+	Fim                  string   `json:"fim"`
+	DiaInteiro           bool     `json:"dia_inteiro"`
+	RecorrenteAnual      bool     `json:"recorrente_anual"`
+	Motivo               string   `json:"motivo"`
+	AgendamentosAfetados []string `json:"agendamentos_afetados,omitempty"` // Agendamentos sinalizados para remarcação
+	CreatedAt            string   `json:"created_at"`
+}
@@ -0,0 +1,39 @@
+package dtos
+
+// FuncionarioCreateDTO representa dados para cadastro de um funcionário do petshop
+type FuncionarioCreateDTO struct {
+	Nome       string   `json:"nome" binding:"required,max=100"`
+	ServicoIDs []string `json:"servico_ids" binding:"required,min=1"` // Serviços que o funcionário realiza
+}
+
+// FuncionarioUpdateDTO representa dados para atualização de um funcionário
+type FuncionarioUpdateDTO struct {
+	Nome       string   `json:"nome" binding:"required,max=100"`
+	Ativo      *bool    `json:"ativo"` // Mantém o valor atual se omitido
+	ServicoIDs []string `json:"servico_ids" binding:"required,min=1"`
+}
+
+// FuncionarioServicoDTO representa um serviço que o funcionário realiza
+type FuncionarioServicoDTO struct {
+	ID   string `json:"id"`
+	Nome string `json:"nome"`
+}
+
+// FuncionarioResponseDTO representa a estrutura de dados de resposta para um funcionário
+type FuncionarioResponseDTO struct {
+	ID        string                  `json:"id"`
+	PetshopID string                  `json:"petshop_id"`
+	Nome      string                  `json:"nome"`
+	Ativo     bool                    `json:"ativo"`
+	Servicos  []FuncionarioServicoDTO `json:"servicos"`
+	CreatedAt string                  `json:"created_at"`
+}
+
+// FuncionarioAgendaDTO representa os agendamentos atribuídos a um funcionário em um período
+type FuncionarioAgendaDTO struct {
+	FuncionarioID string                   `json:"funcionario_id"`
+	Nome          string                   `json:"nome"`
+	De            string                   `json:"de"`
+	Ate           string                   `json:"ate"`
+	Agendamentos  []AgendamentoResponseDTO `json:"agendamentos"`
+}
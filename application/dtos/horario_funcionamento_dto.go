@@ -0,0 +1,37 @@
+package dtos
+
+// IntervaloHorarioDTO representa um intervalo de atendimento no formato HH:MM
+type IntervaloHorarioDTO struct {
+	Inicio string `json:"inicio" binding:"required"` // Formato HH:MM
+	Fim    string `json:"fim" binding:"required"`    // Formato HH:MM (24:00 para fim do dia)
+}
+
+// HorarioDiaDTO representa os intervalos de atendimento de um dia da semana
+type HorarioDiaDTO struct {
+	DiaSemana  int                   `json:"dia_semana" binding:"min=0,max=6"` // 0 = domingo ... 6 = sábado
+	Intervalos []IntervaloHorarioDTO `json:"intervalos" binding:"required,dive"`
+}
+
+// HorarioFuncionamentoUpdateDTO representa a grade semanal completa de um petshop.
+// Dias não informados são considerados fechados
+type HorarioFuncionamentoUpdateDTO struct {
+	Dias []HorarioDiaDTO `json:"dias" binding:"required,dive"`
+}
+
+// HorarioFuncionamentoResponseDTO representa a estrutura de dados de resposta para o horário de funcionamento
+type HorarioFuncionamentoResponseDTO struct {
+	PetshopID   string          `json:"petshop_id"`
+	FusoHorario string          `json:"fuso_horario"`
+	Dias        []HorarioDiaDTO `json:"dias"`
+}
+
+// DisponibilidadeResponseDTO representa os horários de início disponíveis para agendamento em uma data
+type DisponibilidadeResponseDTO struct {
+	PetshopID      string   `json:"petshop_id"`
+	Data           string   `json:"data"`
+	DuracaoMinutos int      `json:"duracao_minutos"`
+	Horarios       []string `json:"horarios"` // Formato ISO8601: 2006-01-02T15:04:05Z07:00
+
+	// Preço que será cobrado pelos serviços consultados
+	Precificacao PrecificacaoDTO `json:"precificacao"`
+}
@@ -0,0 +1,38 @@
+package dtos
+
+// ListaEsperaCreateDTO representa dados para entrar na lista de espera de um petshop.
+// O dono aceita qualquer horário que comece a partir de janela_inicio e termine até janela_fim
+type ListaEsperaCreateDTO struct {
+	DonoID        string                     `json:"dono_id"` // Opcional; quando enviado, deve ser o dono autenticado
+	PetID         string                     `json:"pet_id" binding:"required"`
+	PetshopID     string                     `json:"petshop_id" binding:"required"`
+	JanelaInicio  string                     `json:"janela_inicio" binding:"required"` // Formato ISO8601: 2006-01-02T15:04:05Z07:00
+	JanelaFim     string                     `json:"janela_fim" binding:"required"`    // Formato ISO8601: 2006-01-02T15:04:05Z07:00
+	Observacoes   string                     `json:"observacoes"`
+	TotalPrevisto float64                    `json:"total_previsto" binding:"omitempty,min=0"` // Opcional; conferido com o total calculado
+	Itens         []ItemAgendamentoCreateDTO `json:"itens" binding:"required,min=1,dive"`
+}
+
+// OfertaListaEsperaDTO representa o horário reservado para a entrada até expira_em
+type OfertaListaEsperaDTO struct {
+	Inicio   string `json:"inicio"`
+	Fim      string `json:"fim"`
+	ExpiraEm string `json:"expira_em"`
+}
+
+// ListaEsperaResponseDTO representa a estrutura de dados de resposta para uma entrada da lista de espera
+type ListaEsperaResponseDTO struct {
+	ID            string                       `json:"id"`
+	DonoID        string                       `json:"dono_id"`
+	PetID         string                       `json:"pet_id"`
+	PetshopID     string                       `json:"petshop_id"`
+	JanelaInicio  string                       `json:"janela_inicio"`
+	JanelaFim     string                       `json:"janela_fim"`
+	Status        string                       `json:"status"`
+	Observacoes   string                       `json:"observacoes,omitempty"`
+	TotalPrevisto float64                      `json:"total_previsto"`
+	Itens         []ItemAgendamentoResponseDTO `json:"itens"`
+	Oferta        *OfertaListaEsperaDTO        `json:"oferta,omitempty"`         // Presente enquanto a entrada está ofertada
+	AgendamentoID string                       `json:"agendamento_id,omitempty"` // Agendamento criado ao aceitar a oferta
+	CreatedAt     string                       `json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package dtos
+
+// AgendamentoLoteDTO representa uma operação do petshop aplicada de uma só vez a vários agendamentos,
+// escolhidos pelos ids ou pelo período em que começam (opcionalmente, apenas os de um funcionário)
+type AgendamentoLoteDTO struct {
+	Operacao            string   `json:"operacao" binding:"required,oneof=confirmar cancelar deslocar"`
+	IDs                 []string `json:"ids" binding:"max=200"`
+	Inicio              string   `json:"inicio"` // Formato ISO8601; agendamentos que começam em [inicio, fim)
+	Fim                 string   `json:"fim"`    // Formato ISO8601
+	FuncionarioID       string   `json:"funcionario_id"`
+	Motivo              string   `json:"motivo" binding:"max=500"` // Obrigatório ao cancelar
+	DeslocamentoMinutos int      `json:"deslocamento_minutos"`     // Ao deslocar: minutos somados ao horário (negativo antecipa)
+
+	// Por padrão nada é gravado se algum agendamento falhar; com parcial, os válidos são aplicados
+	Parcial bool `json:"parcial"`
+}
+
+// ResultadoLoteDTO representa o resultado da operação em lote para um agendamento
+type ResultadoLoteDTO struct {
+	AgendamentoID string `json:"agendamento_id"`
+	Sucesso       bool   `json:"sucesso"`
+	Erro          string `json:"erro,omitempty"`
+	Status        string `json:"status,omitempty"`
+	DataAgendada  string `json:"data_agendada,omitempty"`
+}
+
+// AgendamentoLoteResponseDTO representa o relatório de uma operação em lote
+type AgendamentoLoteResponseDTO struct {
+	Operacao   string             `json:"operacao"`
+	Aplicado   bool               `json:"aplicado"` // false quando nenhuma alteração foi gravada
+	Total      int                `json:"total"`
+	Sucessos   int                `json:"sucessos"`
+	Falhas     int                `json:"falhas"`
+	Resultados []ResultadoLoteDTO `json:"resultados"`
+}
@@ -22,10 +22,29 @@ type PetshopCreateDTO struct {
 
 // PetshopUpdateDTO representa a estrutura de dados para atualização básica de um petshop
 type PetshopUpdateDTO struct {
-	Nome      string `json:"nome" binding:"required"`
-	Email     string `json:"email" binding:"required,email"`
-	Telefone  string `json:"telefone" binding:"required"`
-	Descricao string `json:"descricao"`
+	Nome        string `json:"nome" binding:"required"`
+	Email       string `json:"email" binding:"required,email"`
+	Telefone    string `json:"telefone" binding:"required"`
+	Descricao   string `json:"descricao"`
+	Capacidade  int    `json:"capacidade" binding:"omitempty,min=1"`      // Atendimentos simultâneos; mantém o valor atual se omitido
+	FusoHorario string `json:"fuso_horario" binding:"omitempty,timezone"` // Ex.: America/Sao_Paulo; mantém o valor atual se omitido
+
+	// Substitui a política de cancelamento; mantém a atual se omitida
+	PoliticaCancelamento *PoliticaCancelamentoDTO `json:"politica_cancelamento" binding:"omitempty"`
+
+	// Faltas a partir das quais o dono precisa confirmar presença (0 desativa); mantém o valor atual se omitido
+	LimiteFaltasConfirmacao *int `json:"limite_faltas_confirmacao" binding:"omitempty,min=0"`
+
+	// Se verdadeiro, agendamentos confirmados remarcados pelo dono voltam a pendente; mantém o valor atual se omitido
+	ExigeReconfirmacaoRemarcacao *bool `json:"exige_reconfirmacao_remarcacao"`
+}
+
+// PoliticaCancelamentoDTO representa o prazo para cancelamento sem custo e a taxa cobrada do dono
+// em cancelamentos tardios (valor em reais para "fixa" ou em % do total previsto para "percentual")
+type PoliticaCancelamentoDTO struct {
+	PrazoHoras int     `json:"prazo_horas" binding:"min=0,max=720"`
+	TipoTaxa   string  `json:"tipo_taxa" binding:"required,oneof=nenhuma fixa percentual"`
+	ValorTaxa  float64 `json:"valor_taxa" binding:"min=0"`
 }
 
 // PetshopUpdateEnderecoDTO representa a estrutura de dados para atualização do endereço de um petshop
@@ -55,8 +74,15 @@ type PetshopDetailDTO struct {
 	Descricao   string      `json:"descricao,omitempty"`
 	Nota        float32     `json:"nota"`
 	Ativo       bool        `json:"ativo"`
+	Capacidade  int         `json:"capacidade"`
+	FusoHorario string      `json:"fuso_horario"`
 	CreatedAt   string      `json:"created_at"`
 	UpdatedAt   string      `json:"updated_at"`
+
+	PoliticaCancelamento    PoliticaCancelamentoDTO `json:"politica_cancelamento"`
+	LimiteFaltasConfirmacao int                     `json:"limite_faltas_confirmacao"`
+
+	ExigeReconfirmacaoRemarcacao bool `json:"exige_reconfirmacao_remarcacao"`
 }
 
 // PetshopListItemDTO representa a estrutura de dados resumida de um petshop para listagens
@@ -9,7 +9,7 @@ type ItemProcedimentoCreateDTO struct {
 // ProcedimentoCreateDTO representa dados para criação de um novo procedimento
 type ProcedimentoCreateDTO struct {
 	PetID          string                      `json:"pet_id" binding:"required"`
-	PetshopID      string                      `json:"petshop_id" binding:"required"`
+	PetshopID      string                      `json:"-"` // Definido a partir da URL
 	DataRealizacao string                      `json:"data_realizacao" binding:"required"`
 	Observacoes    string                      `json:"observacoes"`
 	Total          float64                     `json:"total" binding:"required,min=0"`
@@ -30,6 +30,7 @@ type ProcedimentoResponseDTO struct {
 	PetID          string                        `json:"pet_id"`
 	NomePet        string                        `json:"nome_pet,omitempty"`
 	PetshopID      string                        `json:"petshop_id"`
+	AgendamentoID  string                        `json:"agendamento_id,omitempty"`
 	NomePetshop    string                        `json:"nome_petshop"`
 	DataRealizacao string                        `json:"data_realizacao"`
 	Observacoes    string                        `json:"observacoes,omitempty"`
@@ -0,0 +1,40 @@
+package dtos
+
+// RecursoCreateDTO representa dados para cadastro de um recurso físico do petshop
+type RecursoCreateDTO struct {
+	Nome       string `json:"nome" binding:"required,max=100"`
+	Quantidade int    `json:"quantidade" binding:"required,min=1"` // Unidades disponíveis (ex.: 2 banheiras)
+}
+
+// RecursoUpdateDTO representa dados para atualização de um recurso
+type RecursoUpdateDTO struct {
+	Nome       string `json:"nome" binding:"required,max=100"`
+	Quantidade int    `json:"quantidade" binding:"required,min=1"`
+}
+
+// ConsumoRecursoDTO representa o uso de um recurso por um serviço
+type ConsumoRecursoDTO struct {
+	RecursoID      string `json:"recurso_id" binding:"required"`
+	DuracaoMinutos int    `json:"duracao_minutos" binding:"omitempty,min=1"` // Omitido, o recurso é usado durante todo o serviço
+}
+
+// ServicoConsumosDTO representa a lista completa de recursos consumidos por um serviço
+type ServicoConsumosDTO struct {
+	Consumos []ConsumoRecursoDTO `json:"consumos" binding:"omitempty,dive"` // Lista vazia remove todos os consumos
+}
+
+// ConsumoRecursoResponseDTO representa o uso do recurso por um serviço na resposta
+type ConsumoRecursoResponseDTO struct {
+	ServicoID      string `json:"servico_id"`
+	DuracaoMinutos int    `json:"duracao_minutos"`
+}
+
+// RecursoResponseDTO representa a estrutura de dados de resposta para um recurso
+type RecursoResponseDTO struct {
+	ID         string                      `json:"id"`
+	PetshopID  string                      `json:"petshop_id"`
+	Nome       string                      `json:"nome"`
+	Quantidade int                         `json:"quantidade"`
+	Consumos   []ConsumoRecursoResponseDTO `json:"consumos"`
+	CreatedAt  string                      `json:"created_at"`
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/henrygoeszanin/api_petshop/domain/entities"
 	"github.com/segmentio/ksuid"
 )
@@ -11,12 +13,66 @@ type AgendamentoRepository interface {
 	Create(agendamento *entities.Agendamento) error
 	GetByID(id ksuid.KSUID) (*entities.Agendamento, error)
 	Update(agendamento *entities.Agendamento) error
-	UpdateStatus(id ksuid.KSUID, status entities.StatusAgendamento) error
+	// UpdateStatus grava o status e os dados de cancelamento do agendamento junto com o histórico.
+	// Os procedimentos informados (conclusão, um por pet) são criados na mesma transação. Retorna
+	// ErrAgendamentoVersionConflict se o agendamento não estiver mais no status e na versão lidos
+	UpdateStatus(agendamento *entities.Agendamento, historico *entities.HistoricoAgendamento, procedimentos []*entities.Procedimento) error
 	Delete(id ksuid.KSUID) error
 
+	// Métodos com verificação de conflito de horário
+	// A função verificar recebe os agendamentos ativos do petshop (e reservas da lista de espera) que se
+	// sobrepõem ao período do agendamento e os fechamentos do petshop, lidos na mesma transação da escrita
+	// com o petshop bloqueado
+	CreateComVerificacao(agendamento *entities.Agendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error
+	UpdateComVerificacao(agendamento *entities.Agendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error
+	// CreateAvulsoComVerificacao funciona como CreateComVerificacao para o agendamento de um cliente avulso,
+	// gravando o cliente na mesma transação quando ele ainda não tem ID
+	CreateAvulsoComVerificacao(cliente *entities.ClienteAvulso, agendamento *entities.Agendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error
+	// Remarcar grava a nova data, o status e o contador de remarcações junto com o histórico, desde que o
+	// agendamento ainda esteja na versão lida (ErrAgendamentoVersionConflict caso contrário); em caso de
+	// sucesso, a versão do agendamento é incrementada
+	Remarcar(agendamento *entities.Agendamento, historico *entities.HistoricoAgendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error
+	// AplicarLote grava as alterações, em ordem, em uma única transação com o petshop bloqueado. Cada agendamento
+	// só é alterado se ainda estiver na versão lida. Retorna o erro de cada alteração que falhou (nil nas gravadas).
+	// Sem parcial, a primeira falha desfaz o lote inteiro e também é retornada como erro; com parcial, apenas a
+	// alteração que falhou é desfeita
+	AplicarLote(alteracoes []entities.AlteracaoAgendamento, parcial bool) ([]error, error)
+	// SalvarItem grava a execução de um item ou cria o item adicionado durante o atendimento, junto com o
+	// término previsto do agendamento. Quando informada, a função verificar é executada como em
+	// UpdateComVerificacao. Retorna ErrAgendamentoVersionConflict se o agendamento não estiver mais
+	// confirmado ou na versão lida; em caso de sucesso, a versão do agendamento é incrementada
+	SalvarItem(agendamento *entities.Agendamento, item *entities.ItemAgendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error
+
 	// Métodos específicos
-	GetByDonoID(donoID ksuid.KSUID) ([]entities.Agendamento, error)
-	GetByPetshopID(petshopID ksuid.KSUID) ([]entities.Agendamento, error)
+	// GetByDonoID e GetByPetshopID retornam a página pedida pelo filtro e o total de agendamentos que o atendem
+	GetByDonoID(donoID ksuid.KSUID, filtro entities.FiltroAgendamentos) ([]entities.Agendamento, int64, error)
+	GetByPetshopID(petshopID ksuid.KSUID, filtro entities.FiltroAgendamentos) ([]entities.Agendamento, int64, error)
 	GetByPetID(petID ksuid.KSUID) ([]entities.Agendamento, error)
 	GetAgendamentosFuturos(petshopID ksuid.KSUID) ([]entities.Agendamento, error)
+	// GetAgendaNoPeriodo busca os agendamentos não cancelados que começam em [inicio, fim), sem reservas da lista de espera
+	GetAgendaNoPeriodo(petshopID ksuid.KSUID, inicio, fim time.Time) ([]entities.Agendamento, error)
+	// GetAtivosNoPeriodo inclui as reservas ativas da lista de espera, representadas como agendamentos
+	GetAtivosNoPeriodo(petshopID ksuid.KSUID, inicio, fim time.Time) ([]entities.Agendamento, error)
+	GetHistorico(agendamentoID ksuid.KSUID) ([]entities.HistoricoAgendamento, error)
+	// GetByFuncionarioNoPeriodo busca os agendamentos ativos atribuídos ao funcionário que se sobrepõem a [inicio, fim)
+	GetByFuncionarioNoPeriodo(funcionarioID ksuid.KSUID, inicio, fim time.Time) ([]entities.Agendamento, error)
+	// ContarPorFuncionario conta os agendamentos ativos de cada funcionário do petshop que começam em [inicio, fim)
+	ContarPorFuncionario(petshopID ksuid.KSUID, inicio, fim time.Time) (map[ksuid.KSUID]int, error)
+	ConfirmarPresenca(id ksuid.KSUID, em time.Time) error
+	// RegistrarCheckIn grava a chegada de um agendamento confirmado ainda sem check-in e RegistrarCheckOut, a saída
+	// de um agendamento confirmado ou concluído com check-in e sem check-out. Retornam ErrNotFound se o agendamento não estiver mais nessa situação
+	RegistrarCheckIn(id ksuid.KSUID, em time.Time) error
+	RegistrarCheckOut(id ksuid.KSUID, em time.Time) error
+	// GetConfiabilidadeDonos retorna o histórico de faltas de cada dono; donos sem faltas ficam fora do mapa
+	GetConfiabilidadeDonos(donoIDs []ksuid.KSUID) (map[ksuid.KSUID]entities.ConfiabilidadeDono, error)
+
+	// Métodos de séries recorrentes
+	CreateSerie(serie *entities.SerieAgendamento) error
+	GetSerieByID(id ksuid.KSUID) (*entities.SerieAgendamento, error)
+	DeleteSerie(id ksuid.KSUID) error
+	GetBySerieID(serieID ksuid.KSUID) ([]entities.Agendamento, error)
+	// CancelarSerie marca a série como cancelada e grava as ocorrências canceladas com seus históricos
+	// (historicos[i] corresponde a agendamentos[i]) em uma única transação. Retorna ErrAgendamentoVersionConflict,
+	// sem gravar nada, se alguma ocorrência não estiver mais no status e na versão lidos
+	CancelarSerie(serieID ksuid.KSUID, agendamentos []entities.Agendamento, historicos []entities.HistoricoAgendamento) error
 }
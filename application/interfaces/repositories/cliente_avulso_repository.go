@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// ClienteAvulsoRepository define os métodos para acesso aos dados de clientes avulsos
+type ClienteAvulsoRepository interface {
+	Create(cliente *entities.ClienteAvulso) error
+	GetByID(id ksuid.KSUID) (*entities.ClienteAvulso, error)
+	// BuscarNoPetshop busca o cliente ainda não vinculado do petshop com o mesmo email ou telefone
+	// e o mesmo nome de pet (sem diferenciar maiúsculas). Email e telefone vazios são ignorados
+	BuscarNoPetshop(petshopID ksuid.KSUID, email, telefone, nomePet string) (*entities.ClienteAvulso, error)
+	// VincularAoDono vincula ao dono o cliente avulso ainda não vinculado. O pet do cliente vira um pet do dono
+	// (o mesmo de outro cliente já vinculado ao dono com o mesmo nome de pet) e os agendamentos do cliente passam
+	// a ser do dono e do pet. Retorna a quantidade de agendamentos vinculados, ou ErrGuestAlreadyLinked
+	VincularAoDono(cliente *entities.ClienteAvulso, dono *entities.Dono) (int, error)
+}
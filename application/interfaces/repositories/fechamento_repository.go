@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// FechamentoRepository define os métodos para acesso aos dados de FechamentoPetshop
+type FechamentoRepository interface {
+	// Create insere o fechamento e, na mesma transação, sinaliza para remarcação os agendamentos
+	// ativos que se sobrepõem a alguma das ocorrências informadas. Retorna os IDs sinalizados
+	Create(fechamento *entities.FechamentoPetshop, ocorrencias []entities.Periodo) ([]ksuid.KSUID, error)
+	GetByID(id ksuid.KSUID) (*entities.FechamentoPetshop, error)
+	Delete(id ksuid.KSUID) error
+
+	// Métodos específicos
+	GetByPetshopID(petshopID ksuid.KSUID) ([]entities.FechamentoPetshop, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// FuncionarioRepository define os métodos para acesso aos dados de Funcionario
+type FuncionarioRepository interface {
+	// Métodos básicos de CRUD
+	Create(funcionario *entities.Funcionario) error
+	GetByID(id ksuid.KSUID) (*entities.Funcionario, error)
+	// Update grava os dados do funcionário e substitui os serviços que ele realiza
+	Update(funcionario *entities.Funcionario) error
+	Delete(id ksuid.KSUID) error
+
+	// Métodos específicos
+	GetByPetshopID(petshopID ksuid.KSUID) ([]entities.Funcionario, error)
+}
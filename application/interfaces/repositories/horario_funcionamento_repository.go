@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// HorarioFuncionamentoRepository define os métodos para acesso aos dados de HorarioFuncionamento
+type HorarioFuncionamentoRepository interface {
+	GetByPetshopID(petshopID ksuid.KSUID) ([]entities.HorarioFuncionamento, error)
+
+	// ReplaceByPetshopID substitui toda a grade semanal do petshop de forma atômica
+	ReplaceByPetshopID(petshopID ksuid.KSUID, horarios []entities.HorarioFuncionamento) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// IdempotenciaRepository define os métodos para acesso às chaves de idempotência
+type IdempotenciaRepository interface {
+	// Reservar grava a chave para o usuário. Se a chave já estiver em uso e não tiver expirado,
+	// nada é gravado e o registro existente é retornado
+	Reservar(chave *entities.ChaveIdempotencia) (*entities.ChaveIdempotencia, error)
+	// Concluir guarda a resposta da requisição original
+	Concluir(id ksuid.KSUID, statusCode int, resposta []byte) error
+	// Liberar remove a chave para que a requisição possa ser tentada novamente
+	Liberar(id ksuid.KSUID) error
+	// RemoverExpiradas remove as chaves que expiraram até o instante informado
+	RemoverExpiradas(ate time.Time) error
+}
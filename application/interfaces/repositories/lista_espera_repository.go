@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// ListaEsperaRepository define os métodos para acesso aos dados de EntradaListaEspera
+type ListaEsperaRepository interface {
+	// Métodos básicos de CRUD
+	Create(entrada *entities.EntradaListaEspera) error
+	GetByID(id ksuid.KSUID) (*entities.EntradaListaEspera, error)
+
+	// Métodos específicos
+	GetByDonoID(donoID ksuid.KSUID) ([]entities.EntradaListaEspera, error)
+	GetByPetshopID(petshopID ksuid.KSUID) ([]entities.EntradaListaEspera, error)
+	// GetAguardandoParaHorario lista, por ordem de chegada, as entradas aguardando cuja janela contém o início informado
+	GetAguardandoParaHorario(petshopID ksuid.KSUID, inicio time.Time) ([]entities.EntradaListaEspera, error)
+	GetOfertasExpiradas(agora time.Time) ([]entities.EntradaListaEspera, error)
+
+	// Ofertar grava a oferta da entrada somente se ela ainda estiver aguardando (ErrNotFound caso contrário)
+	Ofertar(entrada *entities.EntradaListaEspera) error
+	// AtualizarStatus muda o status somente se a entrada estiver no status esperado (ErrNotFound caso contrário)
+	AtualizarStatus(id ksuid.KSUID, de, para entities.StatusListaEspera, agendamentoID *ksuid.KSUID) error
+	// ExpirarJanelasVencidas encerra as entradas aguardando cuja janela de datas já terminou
+	ExpirarJanelasVencidas(agora time.Time) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// RecursoRepository define os métodos para acesso aos dados de Recurso
+type RecursoRepository interface {
+	// Métodos básicos de CRUD
+	Create(recurso *entities.Recurso) error
+	GetByID(id ksuid.KSUID) (*entities.Recurso, error)
+	Update(recurso *entities.Recurso) error
+	// Delete exclui o recurso e os consumos associados a ele
+	Delete(id ksuid.KSUID) error
+
+	// Métodos específicos
+	// GetByPetshopID retorna os recursos do petshop com os consumos de cada serviço
+	GetByPetshopID(petshopID ksuid.KSUID) ([]entities.Recurso, error)
+	// SubstituirConsumosDoServico troca todos os consumos de recursos do serviço pelos informados
+	SubstituirConsumosDoServico(servicoID ksuid.KSUID, consumos []entities.ConsumoRecurso) error
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/henrygoeszanin/api_petshop/application/dtos"
@@ -12,11 +15,18 @@ import (
 
 // AgendamentoService fornece métodos para gerenciar operações de Agendamentos
 type AgendamentoService struct {
-	agendamentoRepository repositories.AgendamentoRepository
-	donoRepository        repositories.DonoRepository
-	petRepository         repositories.PetRepository
-	petshopRepository     repositories.PetshopRepository
-	servicoRepository     repositories.ServicoRepository
+	agendamentoRepository   repositories.AgendamentoRepository
+	donoRepository          repositories.DonoRepository
+	petRepository           repositories.PetRepository
+	petshopRepository       repositories.PetshopRepository
+	servicoRepository       repositories.ServicoRepository
+	horarioRepository       repositories.HorarioFuncionamentoRepository
+	fechamentoRepository    repositories.FechamentoRepository
+	listaEsperaRepository   repositories.ListaEsperaRepository
+	funcionarioRepository   repositories.FuncionarioRepository
+	recursoRepository       repositories.RecursoRepository
+	clienteAvulsoRepository repositories.ClienteAvulsoRepository
+	segredoTicket           []byte // Assina os tickets de check-in
 }
 
 // NewAgendamentoService cria uma nova instância de AgendamentoService
@@ -26,132 +36,458 @@ func NewAgendamentoService(
 	petRepo repositories.PetRepository,
 	petshopRepo repositories.PetshopRepository,
 	servicoRepo repositories.ServicoRepository,
+	horarioRepo repositories.HorarioFuncionamentoRepository,
+	fechamentoRepo repositories.FechamentoRepository,
+	listaEsperaRepo repositories.ListaEsperaRepository,
+	funcionarioRepo repositories.FuncionarioRepository,
+	recursoRepo repositories.RecursoRepository,
+	clienteAvulsoRepo repositories.ClienteAvulsoRepository,
+	segredoTicket string,
 ) *AgendamentoService {
 	return &AgendamentoService{
-		agendamentoRepository: agendamentoRepo,
-		donoRepository:        donoRepo,
-		petRepository:         petRepo,
-		petshopRepository:     petshopRepo,
-		servicoRepository:     servicoRepo,
+		agendamentoRepository:   agendamentoRepo,
+		donoRepository:          donoRepo,
+		petRepository:           petRepo,
+		petshopRepository:       petshopRepo,
+		servicoRepository:       servicoRepo,
+		horarioRepository:       horarioRepo,
+		fechamentoRepository:    fechamentoRepo,
+		listaEsperaRepository:   listaEsperaRepo,
+		funcionarioRepository:   funcionarioRepo,
+		recursoRepository:       recursoRepo,
+		clienteAvulsoRepository: clienteAvulsoRepo,
+		segredoTicket:           []byte(segredoTicket),
 	}
 }
 
 // Create cria um novo agendamento
 func (s *AgendamentoService) Create(dto *dtos.AgendamentoCreateDTO) (*dtos.AgendamentoResponseDTO, error) {
-	// Converter IDs de string para KSUID
-	donoID, err := ksuid.Parse(dto.DonoID)
+	agendamento, participantes, err := s.montarAgendamento(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.agendar(agendamento, participantes.petshop); err != nil {
+		return nil, err
+	}
+
+	// Converter para DTO de resposta
+	return s.entityToResponseDTO(agendamento, participantes.pet.Nome, participantes.dono.Nome, participantes.petshop.Nome), nil
+}
+
+// CreatePeloPetshop cria um agendamento feito pelo petshop por telefone ou no balcão. Com dono_id e pet_id,
+// valem as mesmas validações do agendamento feito pelo dono. Com os dados do cliente, o agendamento é de um
+// cliente sem conta: o cadastro avulso do petshop com o mesmo contato e o mesmo pet é reaproveitado ou criado
+func (s *AgendamentoService) CreatePeloPetshop(petshopID ksuid.KSUID, dto *dtos.AgendamentoPetshopCreateDTO) (*dtos.AgendamentoResponseDTO, error) {
+	if (dto.Cliente == nil) == (dto.DonoID == "") {
+		return nil, errors.ErrInvalidBookingCustomer
+	}
+
+	dadosAgendamento := &dtos.AgendamentoCreateDTO{
+		DonoID:        dto.DonoID,
+		PetID:         dto.PetID,
+		PetshopID:     petshopID.String(),
+		DataAgendada:  dto.DataAgendada,
+		Observacoes:   dto.Observacoes,
+		TotalPrevisto: dto.TotalPrevisto,
+		Itens:         dto.Itens,
+		Pets:          dto.Pets,
+		FuncionarioID: dto.FuncionarioID,
+	}
+
+	// Dono cadastrado
+	if dto.Cliente == nil {
+		agendamento, participantes, err := s.montarAgendamento(dadosAgendamento)
+		if err != nil {
+			return nil, err
+		}
+		agendamento.Origem = entities.OrigemAgendamento(dto.Canal)
+
+		if err := s.agendar(agendamento, participantes.petshop); err != nil {
+			return nil, err
+		}
+		return s.entityToResponseDTO(agendamento, participantes.pet.Nome, participantes.dono.Nome, participantes.petshop.Nome), nil
+	}
+
+	// Cliente sem conta, com um único pet sem cadastro
+	if len(dto.Pets) > 0 {
+		return nil, errors.ErrInvalidAgendamentoPets
+	}
+
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	petAvulso := []petAgendado{{pet: &entities.Pet{Nome: dto.Cliente.NomePet}, itens: dto.Itens}}
+	agendamento, err := s.novoAgendamento(petshop, dadosAgendamento, petAvulso)
+	if err != nil {
+		return nil, err
+	}
+
+	cliente, err := s.clienteAvulso(petshop.ID, dto.Cliente)
+	if err != nil {
+		return nil, err
+	}
+	agendamento.Origem = entities.OrigemAgendamento(dto.Canal)
+
+	if err := s.agendarComCliente(agendamento, petshop, ksuid.Nil, cliente); err != nil {
+		return nil, err
+	}
+	return s.entityToResponseDTO(agendamento, cliente.NomePet, cliente.Nome, petshop.Nome), nil
+}
+
+// clienteAvulso reaproveita o cadastro avulso do petshop com o mesmo contato e o mesmo pet ou prepara um novo,
+// que só é gravado junto com o agendamento. Clientes com o email de um dono cadastrado devem ser agendados pelo dono_id
+func (s *AgendamentoService) clienteAvulso(petshopID ksuid.KSUID, dto *dtos.ClienteAvulsoDTO) (*entities.ClienteAvulso, error) {
+	email := entities.NormalizarEmail(dto.Email)
+	telefone := entities.NormalizarTelefone(dto.Telefone)
+	if email == "" && telefone == "" {
+		return nil, errors.ErrInvalidBookingCustomer
+	}
+
+	if email != "" {
+		dono, err := s.donoRepository.GetByEmail(email)
+		if err != nil && err != errors.ErrNotFound {
+			return nil, errors.ErrFailedToCheckDono
+		}
+		if dono != nil {
+			return nil, errors.ErrClienteAvulsoJaCadastrado
+		}
+	}
+
+	cliente, err := s.clienteAvulsoRepository.BuscarNoPetshop(petshopID, email, telefone, dto.NomePet)
+	if err == nil {
+		return cliente, nil
+	}
+	if err != errors.ErrNotFound {
+		return nil, errors.ErrFailedToFetchGuest
+	}
+
+	return &entities.ClienteAvulso{
+		PetshopID:  petshopID,
+		Nome:       dto.Nome,
+		Email:      email,
+		Telefone:   telefone,
+		NomePet:    dto.NomePet,
+		EspeciePet: dto.EspeciePet,
+		RacaPet:    dto.RacaPet,
+	}, nil
+}
+
+// participantesAgendamento agrupa as entidades envolvidas em um agendamento, já validadas
+type participantesAgendamento struct {
+	dono    *entities.Dono
+	pet     *entities.Pet
+	petshop *entities.Petshop
+}
+
+// nomesParticipantes retorna os nomes do pet e do dono do agendamento. Em agendamentos de clientes
+// avulsos ainda não vinculados, os nomes vêm do cadastro do cliente feito pelo petshop
+func (s *AgendamentoService) nomesParticipantes(agendamento *entities.Agendamento) (nomePet string, nomeDono string, err error) {
+	if agendamento.Avulso() && agendamento.ClienteAvulsoID != nil {
+		cliente, err := s.clienteAvulsoRepository.GetByID(*agendamento.ClienteAvulsoID)
+		if err != nil {
+			return "", "", errors.ErrFailedToFetchDonoInfo
+		}
+		return cliente.NomePet, cliente.Nome, nil
+	}
+
+	pet, err := s.petRepository.GetByID(agendamento.PetID)
 	if err != nil {
-		return nil, errors.ErrInvalidID
+		return "", "", errors.ErrFailedToFetchPetInfo
 	}
 
-	petID, err := ksuid.Parse(dto.PetID)
+	dono, err := s.donoRepository.GetByID(agendamento.DonoID)
+	if err != nil {
+		return "", "", errors.ErrFailedToFetchDonoInfo
+	}
+	return pet.Nome, dono.Nome, nil
+}
+
+// montarAgendamento valida os dados de criação e monta o agendamento com os itens,
+// sem verificar a disponibilidade do horário
+func (s *AgendamentoService) montarAgendamento(dto *dtos.AgendamentoCreateDTO) (*entities.Agendamento, *participantesAgendamento, error) {
+	// Converter IDs de string para KSUID
+	donoID, err := ksuid.Parse(dto.DonoID)
 	if err != nil {
-		return nil, errors.ErrInvalidID
+		return nil, nil, errors.ErrInvalidID
 	}
 
 	petshopID, err := ksuid.Parse(dto.PetshopID)
 	if err != nil {
-		return nil, errors.ErrInvalidID
+		return nil, nil, errors.ErrInvalidID
 	}
 	// Verificar se o dono existe
 	dono, err := s.donoRepository.GetByID(donoID)
 	if err != nil {
 		if err == errors.ErrNotFound {
-			return nil, errors.ErrDonoNotFound
+			return nil, nil, errors.ErrDonoNotFound
 		}
-		return nil, errors.ErrFailedToCheckDono
+		return nil, nil, errors.ErrFailedToCheckDono
 	}
 
-	// Verificar se o pet existe e se pertence ao dono
-	pet, err := s.petRepository.GetByID(petID)
+	// Verificar se os pets existem e se pertencem ao dono
+	pets, err := s.petsAgendados(donoID, dto.PetID, dto.Itens, dto.Pets)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Verificar se o petshop existe
+	petshop, err := s.petshopRepository.GetByID(petshopID)
 	if err != nil {
 		if err == errors.ErrNotFound {
-			return nil, errors.ErrPetNotFound
+			return nil, nil, errors.ErrPetshopNotFound
 		}
-		return nil, errors.ErrFailedToCheckPet
+		return nil, nil, errors.ErrFailedToCheckPetshop
 	}
 
-	if pet.DonoID != donoID {
-		return nil, errors.ErrPetNotOwnedByDono
+	agendamento, err := s.novoAgendamento(petshop, dto, pets)
+	if err != nil {
+		return nil, nil, err
 	}
-	// Verificar se o petshop existe
-	petshop, err := s.petshopRepository.GetByID(petshopID)
+	agendamento.DonoID = donoID
+	agendamento.PetID = pets[0].pet.ID
+
+	// Donos com muitas faltas precisam confirmar presença, se o petshop exigir
+	agendamento.ExigeConfirmacaoPresenca, err = s.exigeConfirmacaoPresenca(petshop, donoID)
 	if err != nil {
-		if err == errors.ErrNotFound {
-			return nil, errors.ErrPetshopNotFound
+		return nil, nil, err
+	}
+
+	return agendamento, &participantesAgendamento{dono: dono, pet: pets[0].pet, petshop: petshop}, nil
+}
+
+// maximoPetsAgendamento é a quantidade máxima de pets em um mesmo agendamento
+const maximoPetsAgendamento = 10
+
+// petAgendado reúne um pet do agendamento e os serviços pedidos para ele.
+// O pet de um cliente avulso não tem cadastro e fica com o ID vazio
+type petAgendado struct {
+	pet   *entities.Pet
+	itens []dtos.ItemAgendamentoCreateDTO
+}
+
+// petsAgendados valida os pets do agendamento, informados por pet_id e itens ou pela lista pets.
+// Cada pet precisa existir, pertencer ao dono e aparecer uma única vez
+func (s *AgendamentoService) petsAgendados(donoID ksuid.KSUID, petID string, itens []dtos.ItemAgendamentoCreateDTO, pets []dtos.PetAgendamentoCreateDTO) ([]petAgendado, error) {
+	if len(pets) == 0 {
+		if petID == "" {
+			return nil, errors.ErrInvalidAgendamentoPets
 		}
-		return nil, errors.ErrFailedToCheckPetshop
+		pets = []dtos.PetAgendamentoCreateDTO{{PetID: petID, Itens: itens}}
+	} else if petID != "" || len(itens) > 0 || len(pets) > maximoPetsAgendamento {
+		return nil, errors.ErrInvalidAgendamentoPets
+	}
+
+	agendados := make([]petAgendado, 0, len(pets))
+	vistos := make(map[ksuid.KSUID]bool, len(pets))
+	for _, petDTO := range pets {
+		id, err := ksuid.Parse(petDTO.PetID)
+		if err != nil {
+			return nil, errors.ErrInvalidID
+		}
+		if vistos[id] || (len(pets) > 1 && len(petDTO.Itens) == 0) {
+			return nil, errors.ErrInvalidAgendamentoPets
+		}
+		vistos[id] = true
+
+		pet, err := s.petRepository.GetByID(id)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrPetNotFound
+			}
+			return nil, errors.ErrFailedToCheckPet
+		}
+		if pet.DonoID != donoID {
+			return nil, errors.ErrPetNotOwnedByDono
+		}
+		agendados = append(agendados, petAgendado{pet: pet, itens: petDTO.Itens})
 	}
+	return agendados, nil
+}
 
+// novoAgendamento monta o agendamento no petshop com a data, os itens de cada pet com os preços calculados
+// e o funcionário escolhido, sem dono nem pet principal
+func (s *AgendamentoService) novoAgendamento(petshop *entities.Petshop, dto *dtos.AgendamentoCreateDTO, pets []petAgendado) (*entities.Agendamento, error) {
 	// Converter data de string para time.Time
 	dataAgendada, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.DataAgendada)
 	if err != nil {
 		return nil, errors.ErrInvalidDate
 	}
 
-	// Validar que a data não é passada
-	if dataAgendada.Before(time.Now()) {
-		return nil, errors.ErrPastDate
-	}
-
 	// Criar entidade Agendamento
 	agendamento := &entities.Agendamento{
-		DonoID:        donoID,
-		PetID:         petID,
-		PetshopID:     petshopID,
-		DataAgendada:  dataAgendada,
-		Status:        entities.StatusPendente,
-		Observacoes:   dto.Observacoes,
-		TotalPrevisto: dto.TotalPrevisto,
-		Itens:         []entities.ItemAgendamento{},
+		PetshopID:    petshop.ID,
+		DataAgendada: dataAgendada,
+		Status:       entities.StatusPendente,
+		Observacoes:  dto.Observacoes,
 	}
 
 	// Processar itens do agendamento
-	var totalCalculado float64
-	for _, itemDTO := range dto.Itens {
-		servicoID, err := ksuid.Parse(itemDTO.ServicoID)
+	agendamento.Itens, agendamento.TotalPrevisto, err = s.itensAgendamento(petshop.ID, pets)
+	if err != nil {
+		return nil, err
+	}
+	// Validar o total previsto, se informado
+	if !precoConfere(dto.TotalPrevisto, agendamento.TotalPrevisto) {
+		return nil, errors.ErrTotalPrevistoMismatch
+	}
+
+	// Funcionário escolhido pelo dono; a habilitação e a disponibilidade são verificadas ao agendar
+	if dto.FuncionarioID != "" {
+		funcionarioID, err := ksuid.Parse(dto.FuncionarioID)
 		if err != nil {
 			return nil, errors.ErrInvalidID
 		}
+		agendamento.FuncionarioID = &funcionarioID
+	}
 
-		// Verificar se o serviço existe e pertence ao petshop
-		servico, err := s.servicoRepository.GetByID(servicoID)
-		if err != nil {
-			if err == errors.ErrNotFound {
-				return nil, errors.ErrServiceNotFound
+	return agendamento, nil
+}
+
+// itensAgendamento monta os itens dos pets, na ordem informada, com os preços do cadastro de cada serviço,
+// e retorna o total calculado. Os serviços precisam existir, pertencer ao petshop e estar ativos
+func (s *AgendamentoService) itensAgendamento(petshopID ksuid.KSUID, pets []petAgendado) ([]entities.ItemAgendamento, float64, error) {
+	itens := []entities.ItemAgendamento{}
+	var totalCalculado float64
+	for _, petAgendado := range pets {
+		for _, itemDTO := range petAgendado.itens {
+			servicoID, err := ksuid.Parse(itemDTO.ServicoID)
+			if err != nil {
+				return nil, 0, errors.ErrInvalidID
 			}
-			return nil, errors.ErrFailedToCheckService
-		}
 
-		if servico.PetshopID != petshopID {
-			return nil, errors.ErrServiceNotFromPetshop
-		}
+			// Verificar se o serviço existe e pertence ao petshop
+			servico, err := s.servicoRepository.GetByID(servicoID)
+			if err != nil {
+				if err == errors.ErrNotFound {
+					return nil, 0, errors.ErrServiceNotFound
+				}
+				return nil, 0, errors.ErrFailedToCheckService
+			}
 
-		if !servico.Ativo {
-			return nil, errors.ErrServiceInactive
+			if servico.PetshopID != petshopID {
+				return nil, 0, errors.ErrServiceNotFromPetshop
+			}
+
+			if !servico.Ativo {
+				return nil, 0, errors.ErrServiceInactive
+			}
+
+			// O preço vem do cadastro do serviço; o informado pelo cliente só é conferido
+			preco := servico.PrecoAgendamento()
+			if !precoConfere(itemDTO.PrecoPrevisto, preco) {
+				return nil, 0, errors.ErrPrecoPrevistoMismatch
+			}
+
+			itens = append(itens, entities.ItemAgendamento{
+				ServicoID:      servicoID,
+				PetID:          petAgendado.pet.ID,
+				NomePet:        petAgendado.pet.Nome,
+				NomeServico:    servico.Nome,
+				PrecoPrevisto:  preco,
+				DuracaoMinutos: int(servico.Duracao() / time.Minute),
+			})
+
+			totalCalculado += preco
 		}
+	}
+	return itens, entities.ArredondarPreco(totalCalculado), nil
+}
+
+// precoConfere indica se o valor enviado pelo cliente coincide com o calculado pelo servidor.
+// Valores zerados são tratados como não informados
+func precoConfere(informado, calculado float64) bool {
+	return informado == 0 || math.Abs(informado-calculado) < 0.005
+}
 
-		// Adicionar item ao agendamento
-		agendamento.Itens = append(agendamento.Itens, entities.ItemAgendamento{
-			ServicoID:     servicoID,
-			NomeServico:   servico.Nome,
-			PrecoPrevisto: itemDTO.PrecoPrevisto,
+// precificacao detalha o preço de cada item e o total previsto do agendamento
+func precificacao(itens []entities.ItemAgendamento) dtos.PrecificacaoDTO {
+	detalhe := dtos.PrecificacaoDTO{Itens: []dtos.ItemPrecificacaoDTO{}}
+	for _, item := range itens {
+		detalhe.Itens = append(detalhe.Itens, dtos.ItemPrecificacaoDTO{
+			ServicoID:   item.ServicoID.String(),
+			NomeServico: item.NomeServico,
+			Preco:       item.PrecoPrevisto,
 		})
+		detalhe.Total += item.PrecoPrevisto
+	}
+	detalhe.Total = entities.ArredondarPreco(detalhe.Total)
+	return detalhe
+}
 
-		totalCalculado += itemDTO.PrecoPrevisto
+// exigeConfirmacaoPresenca verifica se o petshop exige que o dono confirme presença, conforme suas faltas
+func (s *AgendamentoService) exigeConfirmacaoPresenca(petshop *entities.Petshop, donoID ksuid.KSUID) (bool, error) {
+	if petshop.LimiteFaltasConfirmacao <= 0 {
+		return false, nil
 	}
-	// Validar o total previsto
-	if totalCalculado != dto.TotalPrevisto {
-		return nil, errors.ErrTotalPrevistoMismatch
+
+	confiabilidade, err := s.agendamentoRepository.GetConfiabilidadeDonos([]ksuid.KSUID{donoID})
+	if err != nil {
+		return false, errors.ErrFailedToFetchReliability
+	}
+	return petshop.ExigeConfirmacaoPresenca(confiabilidade[donoID]), nil
+}
+
+// agendar valida o horário do agendamento (data futura, horário de funcionamento, fechamentos)
+// e o salva verificando a capacidade do petshop na mesma transação
+func (s *AgendamentoService) agendar(agendamento *entities.Agendamento, petshop *entities.Petshop) error {
+	return s.agendarComReserva(agendamento, petshop, ksuid.Nil)
+}
+
+// agendarComReserva funciona como agendar, mas desconsidera na verificação de capacidade a reserva
+// da lista de espera informada, que está sendo convertida no próprio agendamento
+func (s *AgendamentoService) agendarComReserva(agendamento *entities.Agendamento, petshop *entities.Petshop, reservaID ksuid.KSUID) error {
+	return s.agendarComCliente(agendamento, petshop, reservaID, nil)
+}
+
+// agendarComCliente funciona como agendarComReserva e, com um cliente avulso informado, vincula o agendamento
+// a ele. Um cliente ainda não cadastrado é gravado na mesma transação, para não sobrar cadastro sem agendamento
+func (s *AgendamentoService) agendarComCliente(agendamento *entities.Agendamento, petshop *entities.Petshop, reservaID ksuid.KSUID, cliente *entities.ClienteAvulso) error {
+	// Validar que a data não é passada
+	if agendamento.DataAgendada.Before(time.Now()) {
+		return errors.ErrPastDate
 	}
 
-	// Salvar no repositório
-	if err := s.agendamentoRepository.Create(agendamento); err != nil {
-		return nil, errors.ErrFailedToCreateAgendamento
+	// O período ocupado pelo agendamento é a soma das durações dos serviços
+	agendamento.CalcularDataFim()
+
+	// O agendamento deve caber inteiramente em um intervalo de funcionamento do petshop
+	if err := s.validarHorarioFuncionamento(petshop, agendamento.DataAgendada, agendamento.DataFim); err != nil {
+		return err
 	}
 
-	// Converter para DTO de resposta
-	return s.entityToResponseDTO(agendamento, pet.Nome, dono.Nome, petshop.Nome), nil
+	// Salvar no repositório, verificando conflitos de horário e fechamentos dentro da mesma transação
+	verificar := verificarFechamentos(agendamento, petshop, verificarCapacidade(agendamento, petshop.Capacidade))
+	verificar, err := s.verificarRecursos(agendamento, petshop, verificar)
+	if err != nil {
+		return err
+	}
+	if !reservaID.IsNil() {
+		verificar = ignorarReserva(reservaID, verificar)
+	}
+	verificar, err = s.verificarFuncionario(agendamento, petshop, verificar)
+	if err != nil {
+		return err
+	}
+	if cliente != nil {
+		err = s.agendamentoRepository.CreateAvulsoComVerificacao(cliente, agendamento, verificar)
+	} else {
+		err = s.agendamentoRepository.CreateComVerificacao(agendamento, verificar)
+	}
+	if err != nil {
+		switch err {
+		case errors.ErrHorarioIndisponivel, errors.ErrRecursoIndisponivel, errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable,
+			errors.ErrPetshopClosed, errors.ErrFailedToFetchClosures:
+			return err
+		default:
+			return errors.ErrFailedToCreateAgendamento
+		}
+	}
+	return nil
 }
 
 // GetByID busca um agendamento pelo ID
@@ -163,14 +499,9 @@ func (s *AgendamentoService) GetByID(id ksuid.KSUID) (*dtos.AgendamentoResponseD
 	}
 
 	// Buscar informações adicionais para o DTO
-	pet, err := s.petRepository.GetByID(agendamento.PetID)
-	if err != nil {
-		return nil, errors.ErrFailedToFetchPetInfo
-	}
-
-	dono, err := s.donoRepository.GetByID(agendamento.DonoID)
+	nomePet, nomeDono, err := s.nomesParticipantes(agendamento)
 	if err != nil {
-		return nil, errors.ErrFailedToFetchDonoInfo
+		return nil, err
 	}
 
 	petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
@@ -178,12 +509,17 @@ func (s *AgendamentoService) GetByID(id ksuid.KSUID) (*dtos.AgendamentoResponseD
 		return nil, errors.ErrFailedToFetchPetshopInfo
 	}
 
-	// Converter para DTO de resposta
-	return s.entityToResponseDTO(agendamento, pet.Nome, dono.Nome, petshop.Nome), nil
+	// Converter para DTO de resposta, com o histórico de faltas do dono
+	agendamentosDTO := []dtos.AgendamentoResponseDTO{*s.entityToResponseDTO(agendamento, nomePet, nomeDono, petshop.Nome)}
+	if err := s.anexarConfiabilidade(agendamentosDTO); err != nil {
+		return nil, err
+	}
+	return &agendamentosDTO[0], nil
 }
 
-// GetByDonoID lista todos os agendamentos de um dono
-func (s *AgendamentoService) GetByDonoID(donoID ksuid.KSUID) ([]dtos.AgendamentoResponseDTO, error) {
+// GetByDonoID lista uma página dos agendamentos de um dono que atendem ao filtro.
+// As datas do filtro são interpretadas no fuso horário padrão dos petshops
+func (s *AgendamentoService) GetByDonoID(donoID ksuid.KSUID, filtroDTO *dtos.AgendamentoFiltroDTO) (*dtos.AgendamentoPaginaDTO, error) {
 	// Verificar se o dono existe
 	_, err := s.donoRepository.GetByID(donoID)
 	if err != nil {
@@ -193,42 +529,44 @@ func (s *AgendamentoService) GetByDonoID(donoID ksuid.KSUID) ([]dtos.Agendamento
 		return nil, errors.ErrFailedToCheckDono
 	}
 
+	filtro, err := montarFiltroAgendamentos(filtroDTO, (&entities.Petshop{}).Localizacao())
+	if err != nil {
+		return nil, err
+	}
+
 	// Buscar agendamentos do dono
-	agendamentos, err := s.agendamentoRepository.GetByDonoID(donoID)
+	agendamentos, total, err := s.agendamentoRepository.GetByDonoID(donoID, filtro)
 	if err != nil {
 		return nil, errors.ErrFailedToFetchAgendamentos
 	}
 
-	// Converter para DTO de resposta
-	var agendamentosDTO []dtos.AgendamentoResponseDTO
+	// Converter para DTO de resposta. Um agendamento que não pode ser montado falha a listagem inteira,
+	// para que a página nunca venha mais curta do que o total informado
+	agendamentosDTO := []dtos.AgendamentoResponseDTO{}
 	for _, agendamento := range agendamentos {
 		// Buscar informações adicionais
-		pet, err := s.petRepository.GetByID(agendamento.PetID)
-		if err != nil {
-			continue // Pular este agendamento se não for possível buscar o pet
-		}
-
-		dono, err := s.donoRepository.GetByID(agendamento.DonoID)
+		nomePet, nomeDono, err := s.nomesParticipantes(&agendamento)
 		if err != nil {
-			continue // Pular este agendamento se não for possível buscar o dono
+			return nil, err
 		}
 
 		petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
 		if err != nil {
-			continue // Pular este agendamento se não for possível buscar o petshop
+			return nil, errors.ErrFailedToFetchPetshopInfo
 		}
 
 		// Adicionar agendamento convertido
-		agendamentosDTO = append(agendamentosDTO, *s.entityToResponseDTO(&agendamento, pet.Nome, dono.Nome, petshop.Nome))
+		agendamentosDTO = append(agendamentosDTO, *s.entityToResponseDTO(&agendamento, nomePet, nomeDono, petshop.Nome))
 	}
 
-	return agendamentosDTO, nil
+	return paginaAgendamentos(agendamentosDTO, total, filtro), nil
 }
 
-// GetByPetshopID lista todos os agendamentos de um petshop
-func (s *AgendamentoService) GetByPetshopID(petshopID ksuid.KSUID) ([]dtos.AgendamentoResponseDTO, error) {
+// GetByPetshopID lista uma página dos agendamentos de um petshop que atendem ao filtro.
+// As datas do filtro são interpretadas no fuso horário do petshop
+func (s *AgendamentoService) GetByPetshopID(petshopID ksuid.KSUID, filtroDTO *dtos.AgendamentoFiltroDTO) (*dtos.AgendamentoPaginaDTO, error) {
 	// Verificar se o petshop existe
-	_, err := s.petshopRepository.GetByID(petshopID)
+	petshopFiltro, err := s.petshopRepository.GetByID(petshopID)
 	if err != nil {
 		if err == errors.ErrNotFound {
 			return nil, errors.ErrPetshopNotFound
@@ -236,95 +574,139 @@ func (s *AgendamentoService) GetByPetshopID(petshopID ksuid.KSUID) ([]dtos.Agend
 		return nil, errors.ErrFailedToCheckPetshop
 	}
 
+	filtro, err := montarFiltroAgendamentos(filtroDTO, petshopFiltro.Localizacao())
+	if err != nil {
+		return nil, err
+	}
+
 	// Buscar agendamentos do petshop
-	agendamentos, err := s.agendamentoRepository.GetByPetshopID(petshopID)
+	agendamentos, total, err := s.agendamentoRepository.GetByPetshopID(petshopID, filtro)
 	if err != nil {
 		return nil, errors.ErrFailedToFetchAgendamentos
 	}
 
-	// Converter para DTO de resposta
-	var agendamentosDTO []dtos.AgendamentoResponseDTO
+	// Converter para DTO de resposta. Um agendamento que não pode ser montado falha a listagem inteira,
+	// para que a página nunca venha mais curta do que o total informado
+	agendamentosDTO := []dtos.AgendamentoResponseDTO{}
 	for _, agendamento := range agendamentos {
 		// Buscar informações adicionais
-		pet, err := s.petRepository.GetByID(agendamento.PetID)
-		if err != nil {
-			continue // Pular este agendamento se não for possível buscar o pet
-		}
-
-		dono, err := s.donoRepository.GetByID(agendamento.DonoID)
-		if err != nil {
-			continue // Pular este agendamento se não for possível buscar o dono
-		}
-
-		petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+		nomePet, nomeDono, err := s.nomesParticipantes(&agendamento)
 		if err != nil {
-			continue // Pular este agendamento se não for possível buscar o petshop
+			return nil, err
 		}
 
 		// Adicionar agendamento convertido
-		agendamentosDTO = append(agendamentosDTO, *s.entityToResponseDTO(&agendamento, pet.Nome, dono.Nome, petshop.Nome))
+		agendamentosDTO = append(agendamentosDTO, *s.entityToResponseDTO(&agendamento, nomePet, nomeDono, petshopFiltro.Nome))
 	}
 
-	return agendamentosDTO, nil
-}
-
-// UpdateStatus atualiza o status de um agendamento
-func (s *AgendamentoService) UpdateStatus(id ksuid.KSUID, dto *dtos.AgendamentoUpdateStatusDTO) (*dtos.AgendamentoResponseDTO, error) {
-	// Verificar se o agendamento existe
-	agendamento, err := s.agendamentoRepository.GetByID(id)
-	if err != nil {
-		if err == errors.ErrNotFound {
-			return nil, errors.ErrNotFound
-		}
-		return nil, errors.ErrFailedToCheckAgendamento
+	// Anexar o histórico de faltas de cada dono para o petshop avaliar os agendamentos
+	if err := s.anexarConfiabilidade(agendamentosDTO); err != nil {
+		return nil, err
 	}
 
-	// Validar a transição de status
-	statusAtual := agendamento.Status
-	novoStatus := entities.StatusAgendamento(dto.Status)
+	return paginaAgendamentos(agendamentosDTO, total, filtro), nil
+}
 
-	// Validações específicas de acordo com regras de negócio
-	if statusAtual == entities.StatusCancelado && novoStatus != entities.StatusCancelado {
-		return nil, errors.ErrUpdateCanceledAgendamento
+// montarFiltroAgendamentos valida os filtros recebidos na listagem e os converte para o filtro do
+// repositório. As datas de e ate (inclusivas) são interpretadas no fuso informado
+func montarFiltroAgendamentos(dto *dtos.AgendamentoFiltroDTO, loc *time.Location) (entities.FiltroAgendamentos, error) {
+	filtro := entities.FiltroAgendamentos{
+		Ordenar: "data_agendada",
+		Pagina:  dto.Page,
+		Limite:  dto.Limit,
+	}
+	if filtro.Pagina < 1 {
+		filtro.Pagina = 1
+	}
+	if filtro.Limite < 1 || filtro.Limite > 100 {
+		filtro.Limite = 10
 	}
 
-	if statusAtual == entities.StatusConcluido && novoStatus != entities.StatusConcluido {
-		return nil, errors.ErrUpdateCompletedAgendamento
+	if dto.De != "" {
+		de, err := time.ParseInLocation("2006-01-02", dto.De, loc)
+		if err != nil {
+			return filtro, errors.ErrInvalidDate
+		}
+		filtro.De = &de
+	}
+	if dto.Ate != "" {
+		ate, err := time.ParseInLocation("2006-01-02", dto.Ate, loc)
+		if err != nil {
+			return filtro, errors.ErrInvalidDate
+		}
+		ate = ate.AddDate(0, 0, 1)
+		filtro.Ate = &ate
+	}
+	if filtro.De != nil && filtro.Ate != nil && !filtro.Ate.After(*filtro.De) {
+		return filtro, errors.ErrInvalidFilterPeriod
 	}
 
-	// Atualizar status no banco de dados
-	if err := s.agendamentoRepository.UpdateStatus(id, novoStatus); err != nil {
-		return nil, errors.ErrFailedToUpdateStatus
+	if dto.Status != "" {
+		for _, statusStr := range strings.Split(dto.Status, ",") {
+			status := entities.StatusAgendamento(strings.TrimSpace(statusStr))
+			if !status.Valido() {
+				return filtro, errors.ErrInvalidAgendamentoStatus
+			}
+			filtro.Status = append(filtro.Status, status)
+		}
 	}
 
-	// Buscar agendamento atualizado
-	agendamentoAtualizado, err := s.agendamentoRepository.GetByID(id)
-	if err != nil {
-		return nil, errors.ErrFailedToCheckAgendamento
+	if dto.PetID != "" {
+		petID, err := ksuid.Parse(dto.PetID)
+		if err != nil {
+			return filtro, errors.ErrInvalidID
+		}
+		filtro.PetID = &petID
 	}
-	// Buscar informações adicionais para o DTO
-	pet, err := s.petRepository.GetByID(agendamentoAtualizado.PetID)
-	if err != nil {
-		return nil, errors.ErrFailedToFetchPetInfo
+	if dto.ServicoID != "" {
+		servicoID, err := ksuid.Parse(dto.ServicoID)
+		if err != nil {
+			return filtro, errors.ErrInvalidID
+		}
+		filtro.ServicoID = &servicoID
 	}
 
-	dono, err := s.donoRepository.GetByID(agendamentoAtualizado.DonoID)
-	if err != nil {
-		return nil, errors.ErrFailedToFetchDonoInfo
+	if dto.Ordenar != "" {
+		valido := false
+		for _, campo := range entities.CamposOrdenacaoAgendamentos {
+			if dto.Ordenar == campo {
+				valido = true
+			}
+		}
+		if !valido {
+			return filtro, errors.ErrInvalidSortField
+		}
+		filtro.Ordenar = dto.Ordenar
 	}
 
-	petshop, err := s.petshopRepository.GetByID(agendamentoAtualizado.PetshopID)
-	if err != nil {
-		return nil, errors.ErrFailedToFetchPetshopInfo
+	switch strings.ToLower(dto.Ordem) {
+	case "", "desc":
+	case "asc":
+		filtro.Crescente = true
+	default:
+		return filtro, errors.ErrInvalidSortField
 	}
 
-	// Converter para DTO de resposta
-	return s.entityToResponseDTO(agendamentoAtualizado, pet.Nome, dono.Nome, petshop.Nome), nil
+	return filtro, nil
 }
 
-// Update atualiza os dados de um agendamento
-func (s *AgendamentoService) Update(id ksuid.KSUID, dto *dtos.AgendamentoUpdateDTO) (*dtos.AgendamentoResponseDTO, error) {
-	// Verificar se o agendamento existe
+// paginaAgendamentos monta a resposta paginada a partir dos agendamentos convertidos e do total do filtro
+func paginaAgendamentos(agendamentosDTO []dtos.AgendamentoResponseDTO, total int64, filtro entities.FiltroAgendamentos) *dtos.AgendamentoPaginaDTO {
+	return &dtos.AgendamentoPaginaDTO{
+		Agendamentos: agendamentosDTO,
+		Total:        total,
+		Page:         filtro.Pagina,
+		Limit:        filtro.Limite,
+		TotalPaginas: int((total + int64(filtro.Limite) - 1) / int64(filtro.Limite)),
+	}
+}
+
+// ConfirmarPresenca registra que o dono confirmou que comparecerá ao agendamento
+func (s *AgendamentoService) ConfirmarPresenca(id ksuid.KSUID, ator entities.Ator) (*dtos.AgendamentoResponseDTO, error) {
+	if ator.Tipo != entities.AtorDono {
+		return nil, errors.ErrOnlyDonoConfirmsPresence
+	}
+
 	agendamento, err := s.agendamentoRepository.GetByID(id)
 	if err != nil {
 		if err == errors.ErrNotFound {
@@ -333,37 +715,792 @@ func (s *AgendamentoService) Update(id ksuid.KSUID, dto *dtos.AgendamentoUpdateD
 		return nil, errors.ErrFailedToCheckAgendamento
 	}
 
-	// Não permitir atualização de agendamentos cancelados ou concluídos
-	if agendamento.Status == entities.StatusCancelado || agendamento.Status == entities.StatusConcluido {
+	if !agendamento.Status.OcupaAgenda() {
 		return nil, errors.ErrAgendamentoUpdateForbidden
 	}
 
-	// Converter data de string para time.Time
-	dataAgendada, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.DataAgendada)
-	if err != nil {
-		return nil, errors.ErrInvalidDate
+	if agendamento.PresencaConfirmadaEm == nil {
+		if err := s.agendamentoRepository.ConfirmarPresenca(id, time.Now()); err != nil {
+			return nil, errors.ErrFailedToUpdateAgendamento
+		}
 	}
 
-	// Validar que a data não é passada
-	if dataAgendada.Before(time.Now()) {
-		return nil, errors.ErrPastDate
+	return s.GetByID(id)
+}
+
+// anexarConfiabilidade preenche o histórico de faltas do dono em cada agendamento, com uma única consulta
+func (s *AgendamentoService) anexarConfiabilidade(agendamentosDTO []dtos.AgendamentoResponseDTO) error {
+	if len(agendamentosDTO) == 0 {
+		return nil
+	}
+
+	var donoIDs []ksuid.KSUID
+	for _, agendamentoDTO := range agendamentosDTO {
+		if agendamentoDTO.DonoID == "" {
+			continue // Clientes avulsos não têm histórico de faltas
+		}
+		donoID, err := ksuid.Parse(agendamentoDTO.DonoID)
+		if err != nil {
+			return errors.ErrInvalidID
+		}
+		donoIDs = append(donoIDs, donoID)
+	}
+
+	confiabilidades, err := s.agendamentoRepository.GetConfiabilidadeDonos(donoIDs)
+	if err != nil {
+		return errors.ErrFailedToFetchReliability
+	}
+
+	for i := range agendamentosDTO {
+		if agendamentosDTO[i].DonoID == "" {
+			continue
+		}
+		donoID, _ := ksuid.Parse(agendamentosDTO[i].DonoID)
+		confiabilidade := confiabilidades[donoID]
+
+		var ultimo string
+		if confiabilidade.UltimoNaoComparecimento != nil {
+			ultimo = confiabilidade.UltimoNaoComparecimento.Format(time.RFC3339)
+		}
+		agendamentosDTO[i].ConfiabilidadeDono = &dtos.ConfiabilidadeDonoDTO{
+			NaoComparecimentos:      confiabilidade.NaoComparecimentos,
+			UltimoNaoComparecimento: ultimo,
+		}
+	}
+	return nil
+}
+
+// UpdateStatus atualiza o status de um agendamento, respeitando as transições permitidas ao ator
+func (s *AgendamentoService) UpdateStatus(id ksuid.KSUID, dto *dtos.AgendamentoUpdateStatusDTO, ator entities.Ator) (*dtos.AgendamentoResponseDTO, error) {
+	// Verificar se o agendamento existe
+	agendamento, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	// Validar a transição de status
+	statusAtual := agendamento.Status
+	novoStatus := entities.StatusAgendamento(dto.Status)
+	if err := validarTransicaoStatus(statusAtual, novoStatus, ator); err != nil {
+		return nil, err
+	}
+
+	// A falta só pode ser registrada depois do horário agendado
+	if novoStatus == entities.StatusNaoCompareceu && time.Now().Before(agendamento.DataAgendada) {
+		return nil, errors.ErrNoShowBeforeSchedule
+	}
+
+	// Com o check-in registrado, o pet compareceu
+	if novoStatus == entities.StatusNaoCompareceu && agendamento.CheckInEm != nil {
+		return nil, errors.ErrNoShowAfterCheckIn
+	}
+
+	// Donos com muitas faltas precisam confirmar presença antes da confirmação do petshop
+	if novoStatus == entities.StatusConfirmado && agendamento.AguardandoConfirmacaoPresenca() {
+		return nil, errors.ErrAwaitingPresenceConfirm
+	}
+
+	// Ao concluir, gerar o procedimento de cada pet a partir dos itens do agendamento
+	var procedimentos []*entities.Procedimento
+	if novoStatus == entities.StatusConcluido {
+		procedimentos, err = s.gerarProcedimentos(agendamento, dto.PrecosFinais)
+		if err != nil {
+			return nil, err
+		}
+		// Clientes avulsos não têm pet cadastrado em que registrar o procedimento
+		if agendamento.Avulso() {
+			procedimentos = nil
+		}
+	} else if len(dto.PrecosFinais) > 0 {
+		return nil, errors.ErrFinalPricesNotAllowed
+	}
+
+	// Ao cancelar, registrar se foi dentro do prazo e a taxa devida conforme a política do petshop
+	agendamento.Status = novoStatus
+	if novoStatus == entities.StatusCancelado {
+		petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+		if err != nil {
+			return nil, errors.ErrFailedToFetchPetshopInfo
+		}
+		registrarCancelamento(agendamento, petshop, ator, time.Now())
+	}
+
+	// Atualizar status no banco de dados, registrando a mudança no histórico
+	historico := &entities.HistoricoAgendamento{
+		AgendamentoID:  id,
+		StatusAnterior: statusAtual,
+		StatusNovo:     novoStatus,
+		AtorID:         ator.ID,
+		AtorTipo:       ator.Tipo,
+		Motivo:         dto.Motivo,
+	}
+	// Outra alteração gravada desde a leitura (ex.: duas conclusões simultâneas) invalida esta transição
+	if err := s.agendamentoRepository.UpdateStatus(agendamento, historico, procedimentos); err != nil {
+		if err == errors.ErrAgendamentoVersionConflict {
+			return nil, err
+		}
+		return nil, errors.ErrFailedToUpdateStatus
+	}
+
+	// O horário liberado pelo cancelamento é ofertado à lista de espera
+	if novoStatus == entities.StatusCancelado {
+		s.ofertarHorarioLiberado(agendamento.PetshopID, agendamento.DataAgendada)
+	}
+
+	// Buscar agendamento atualizado
+	agendamentoAtualizado, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+	// Buscar informações adicionais para o DTO
+	nomePet, nomeDono, err := s.nomesParticipantes(agendamentoAtualizado)
+	if err != nil {
+		return nil, err
+	}
+
+	petshop, err := s.petshopRepository.GetByID(agendamentoAtualizado.PetshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	// Converter para DTO de resposta
+	return s.entityToResponseDTO(agendamentoAtualizado, nomePet, nomeDono, petshop.Nome), nil
+}
+
+// validarTransicaoStatus verifica se o ator pode mover o agendamento do status atual para o novo status
+func validarTransicaoStatus(statusAtual, novoStatus entities.StatusAgendamento, ator entities.Ator) error {
+	// Status finais não podem ser alterados
+	if statusAtual == entities.StatusCancelado {
+		return errors.ErrUpdateCanceledAgendamento
+	}
+
+	if statusAtual == entities.StatusConcluido {
+		return errors.ErrUpdateCompletedAgendamento
+	}
+
+	if statusAtual == entities.StatusNaoCompareceu {
+		return errors.ErrUpdateNoShowAgendamento
+	}
+
+	// Validar a transição de acordo com o tipo de usuário
+	if !statusAtual.PodeTransicionarPara(novoStatus, ator.Tipo) {
+		if statusAtual.TransicaoExiste(novoStatus) {
+			return errors.ErrStatusTransitionForbidden
+		}
+		return errors.ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+// Update atualiza as observações e os serviços de um agendamento; a data só muda por Remarcar. Com
+// versaoEsperada diferente de zero (If-Match), a atualização só ocorre se o agendamento ainda estiver nessa
+// versão. Em qualquer caso, alterações gravadas por outra requisição entre a leitura e a gravação resultam
+// em ErrAgendamentoVersionConflict
+func (s *AgendamentoService) Update(id ksuid.KSUID, dto *dtos.AgendamentoUpdateDTO, versaoEsperada int) (*dtos.AgendamentoResponseDTO, error) {
+	// Verificar se o agendamento existe
+	agendamento, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	// O cliente editou uma versão que já foi substituída
+	if versaoEsperada != 0 && agendamento.Versao != versaoEsperada {
+		return nil, errors.ErrAgendamentoVersionConflict
+	}
+
+	// Não permitir atualização de agendamentos encerrados (cancelados, concluídos ou com falta)
+	if agendamento.Status.Encerrado() {
+		return nil, errors.ErrAgendamentoUpdateForbidden
+	}
+
+	// Substituir os itens apagaria a execução registrada durante o atendimento e os itens incluídos no balcão
+	if agendamento.AtendimentoIniciado() {
+		return nil, errors.ErrServiceAlreadyStarted
+	}
+
+	// A data só muda pela remarcação, que conta as remarcações, registra o histórico e aplica a reconfirmação
+	if dto.DataAgendada != "" {
+		dataAgendada, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.DataAgendada)
+		if err != nil {
+			return nil, errors.ErrInvalidDate
+		}
+		if !dataAgendada.Equal(agendamento.DataAgendada) {
+			return nil, errors.ErrUseRescheduleForDate
+		}
+	}
+
+	// Validar que o agendamento ainda não passou
+	if agendamento.DataAgendada.Before(time.Now()) {
+		return nil, errors.ErrPastDate
 	}
 
 	// Atualizar campos
-	agendamento.DataAgendada = dataAgendada
 	agendamento.Observacoes = dto.Observacoes
-	agendamento.TotalPrevisto = dto.TotalPrevisto
+
+	// Agendamentos com vários pets são alterados pela lista pets; com um pet, os itens são do pet atual
+	var pets []petAgendado
+	if len(dto.Pets) == 0 && agendamento.QuantidadePets() == 1 {
+		nomePet, _, err := s.nomesParticipantes(agendamento)
+		if err != nil {
+			return nil, err
+		}
+		pets = []petAgendado{{pet: &entities.Pet{ID: agendamento.PetID, Nome: nomePet}, itens: dto.Itens}}
+	} else {
+		pets, err = s.petsAgendados(agendamento.DonoID, "", dto.Itens, dto.Pets)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Processar itens do agendamento
-	itens := []entities.ItemAgendamento{}
-	var totalCalculado float64
-	for _, itemDTO := range dto.Itens {
-		servicoID, err := ksuid.Parse(itemDTO.ServicoID)
+	itens, total, err := s.itensAgendamento(agendamento.PetshopID, pets)
+	if err != nil {
+		return nil, err
+	}
+	for i := range itens {
+		itens[i].AgendamentoID = agendamento.ID
+	}
+	// Validar o total previsto, se informado
+	agendamento.TotalPrevisto = total
+	if !precoConfere(dto.TotalPrevisto, agendamento.TotalPrevisto) {
+		return nil, errors.ErrTotalPrevistoMismatch
+	}
+
+	// Atualizar itens, o pet principal e o período ocupado
+	agendamento.PetID = pets[0].pet.ID
+	agendamento.Itens = itens
+	agendamento.CalcularDataFim()
+
+	petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	// O agendamento deve caber inteiramente em um intervalo de funcionamento do petshop
+	if err := s.validarHorarioFuncionamento(petshop, agendamento.DataAgendada, agendamento.DataFim); err != nil {
+		return nil, err
+	}
+
+	// O petshop não pode estar fechado no período, e os recursos físicos e o funcionário atribuído precisam
+	// estar livres no novo período, com o funcionário realizando os novos serviços
+	verificar := verificarFechamentos(agendamento, petshop, verificarCapacidade(agendamento, petshop.Capacidade))
+	verificar, err = s.verificarRecursos(agendamento, petshop, verificar)
+	if err != nil {
+		return nil, err
+	}
+	verificar, err = s.verificarFuncionario(agendamento, petshop, verificar)
+	if err != nil {
+		return nil, err
+	}
+
+	// Salvar no repositório, verificando conflitos de horário dentro da mesma transação
+	if err := s.agendamentoRepository.UpdateComVerificacao(agendamento, verificar); err != nil {
+		switch err {
+		case errors.ErrHorarioIndisponivel, errors.ErrRecursoIndisponivel, errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable,
+			errors.ErrPetshopClosed, errors.ErrFailedToFetchClosures, errors.ErrNotFound, errors.ErrAgendamentoVersionConflict:
+			return nil, err
+		default:
+			return nil, errors.ErrFailedToUpdateAgendamento
+		}
+	}
+
+	// Buscar informações adicionais para o DTO
+	nomePet, nomeDono, err := s.nomesParticipantes(agendamento)
+	if err != nil {
+		return nil, err
+	}
+
+	// Converter para DTO de resposta
+	return s.entityToResponseDTO(agendamento, nomePet, nomeDono, petshop.Nome), nil
+}
+
+// Remarcar move o agendamento para outra data mantendo serviços, preços e identidade. A nova data passa pelas
+// mesmas validações da criação; se o petshop exigir, um agendamento confirmado remarcado pelo dono volta a pendente.
+// Com versaoEsperada diferente de zero (If-Match), a remarcação só ocorre se o agendamento ainda estiver nessa versão
+func (s *AgendamentoService) Remarcar(id ksuid.KSUID, dto *dtos.AgendamentoRemarcarDTO, ator entities.Ator, versaoEsperada int) (*dtos.AgendamentoResponseDTO, error) {
+	agendamento, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	// O cliente remarcou a partir de uma versão que já foi substituída
+	if versaoEsperada != 0 && agendamento.Versao != versaoEsperada {
+		return nil, errors.ErrAgendamentoVersionConflict
+	}
+
+	// Agendamentos encerrados não podem ser remarcados
+	if agendamento.Status.Encerrado() {
+		return nil, errors.ErrAgendamentoUpdateForbidden
+	}
+
+	// Após o check-in ou o registro da execução de algum serviço, o atendimento não muda mais de data
+	if agendamento.AtendimentoIniciado() {
+		return nil, errors.ErrRescheduleAfterStart
+	}
+
+	novaData, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.DataAgendada)
+	if err != nil {
+		return nil, errors.ErrInvalidDate
+	}
+
+	if novaData.Equal(agendamento.DataAgendada) {
+		return nil, errors.ErrRescheduleSameDate
+	}
+
+	if novaData.Before(time.Now()) {
+		return nil, errors.ErrPastDate
+	}
+
+	petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	dataAnterior := agendamento.DataAgendada
+	statusAnterior := agendamento.Status
+
+	agendamento.DataAgendada = novaData
+	agendamento.CalcularDataFim()
+
+	// O agendamento deve caber inteiramente em um intervalo de funcionamento do petshop
+	if err := s.validarHorarioFuncionamento(petshop, agendamento.DataAgendada, agendamento.DataFim); err != nil {
+		return nil, err
+	}
+
+	// Remarcações do próprio petshop já valem como confirmação
+	if statusAnterior == entities.StatusConfirmado && ator.Tipo == entities.AtorDono && petshop.ExigeReconfirmacaoRemarcacao {
+		agendamento.Status = entities.StatusPendente
+	}
+
+	// Uma nova data resolve a necessidade de remarcação causada por fechamento
+	agendamento.Remarcacoes++
+	agendamento.RequerRemarcacao = false
+	agendamento.MotivoRemarcacao = ""
+
+	historico := &entities.HistoricoAgendamento{
+		AgendamentoID:  id,
+		StatusAnterior: statusAnterior,
+		StatusNovo:     agendamento.Status,
+		AtorID:         ator.ID,
+		AtorTipo:       ator.Tipo,
+		Motivo:         dto.Motivo,
+		DataAnterior:   &dataAnterior,
+		DataNova:       &novaData,
+	}
+
+	// O petshop não pode estar fechado na nova data, e os recursos físicos e o funcionário atribuído precisam estar livres
+	verificar := verificarFechamentos(agendamento, petshop, verificarCapacidade(agendamento, petshop.Capacidade))
+	verificar, err = s.verificarRecursos(agendamento, petshop, verificar)
+	if err != nil {
+		return nil, err
+	}
+	verificar, err = s.verificarFuncionario(agendamento, petshop, verificar)
+	if err != nil {
+		return nil, err
+	}
+
+	// Salvar no repositório, verificando conflitos de horário e fechamentos dentro da mesma transação
+	if err := s.agendamentoRepository.Remarcar(agendamento, historico, verificar); err != nil {
+		switch err {
+		case errors.ErrHorarioIndisponivel, errors.ErrRecursoIndisponivel, errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable,
+			errors.ErrPetshopClosed, errors.ErrFailedToFetchClosures, errors.ErrAgendamentoVersionConflict:
+			return nil, err
+		default:
+			return nil, errors.ErrFailedToReschedule
+		}
+	}
+
+	// O horário liberado é ofertado à lista de espera
+	s.ofertarHorarioLiberado(agendamento.PetshopID, dataAnterior)
+
+	return s.GetByID(id)
+}
+
+// registrarCancelamento preenche os dados de cancelamento do agendamento. Apenas cancelamentos
+// feitos pelo dono estão sujeitos à política do petshop; os feitos pelo petshop nunca geram taxa
+func registrarCancelamento(agendamento *entities.Agendamento, petshop *entities.Petshop, ator entities.Ator, em time.Time) {
+	agendamento.CanceladoEm = &em
+	agendamento.CancelamentoNoPrazo = true
+	agendamento.TaxaCancelamento = 0
+	if ator.Tipo == entities.AtorDono {
+		agendamento.CancelamentoNoPrazo, agendamento.TaxaCancelamento = petshop.AvaliarCancelamento(agendamento, em)
+	}
+}
+
+// gerarProcedimentos monta os procedimentos de um agendamento concluído, um para cada pet, copiando os
+// itens não pulados. O preço final de cada item é o informado na conclusão, o registrado durante o
+// atendimento ou, na falta de ambos, o previsto
+func (s *AgendamentoService) gerarProcedimentos(agendamento *entities.Agendamento, precosFinais []dtos.PrecoFinalItemDTO) ([]*entities.Procedimento, error) {
+	petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	// Indexar os preços finais informados pelo ID do item do agendamento
+	precoPorItem := make(map[ksuid.KSUID]float64)
+	for _, precoDTO := range precosFinais {
+		itemID, err := ksuid.Parse(precoDTO.ItemID)
+		if err != nil {
+			return nil, errors.ErrInvalidID
+		}
+		precoPorItem[itemID] = precoDTO.PrecoFinal
+	}
+
+	agendamentoID := agendamento.ID
+	var procedimentos []*entities.Procedimento
+	procedimentoPorPet := make(map[ksuid.KSUID]*entities.Procedimento)
+	for i, item := range agendamento.Itens {
+		// Serviços pulados não são cobrados nem entram no procedimento
+		if !item.Cobrado() {
+			if _, ok := precoPorItem[item.ID]; ok {
+				return nil, errors.ErrSkippedItemPriced
+			}
+			continue
+		}
+
+		petID := agendamento.PetDoItem(&agendamento.Itens[i])
+		procedimento, ok := procedimentoPorPet[petID]
+		if !ok {
+			procedimento = &entities.Procedimento{
+				PetID:          petID,
+				PetshopID:      agendamento.PetshopID,
+				AgendamentoID:  &agendamentoID,
+				NomePetshop:    petshop.Nome,
+				DataRealizacao: agendamento.DataAgendada,
+				Observacoes:    agendamento.Observacoes,
+				Itens:          []entities.ItemProcedimento{},
+			}
+			procedimentoPorPet[petID] = procedimento
+			procedimentos = append(procedimentos, procedimento)
+		}
+
+		precoFinal := item.PrecoCobrado()
+		if preco, ok := precoPorItem[item.ID]; ok {
+			precoFinal = preco
+			delete(precoPorItem, item.ID)
+		}
+
+		procedimento.Itens = append(procedimento.Itens, entities.ItemProcedimento{
+			ServicoID:   item.ServicoID,
+			NomeServico: item.NomeServico,
+			PrecoFinal:  precoFinal,
+		})
+		procedimento.Total += precoFinal
+	}
+
+	// Todo preço informado deve corresponder a um item do agendamento
+	if len(precoPorItem) > 0 {
+		return nil, errors.ErrItemNotFromAgendamento
+	}
+
+	return procedimentos, nil
+}
+
+// GetHistorico lista as mudanças de status e remarcações de um agendamento em ordem cronológica
+func (s *AgendamentoService) GetHistorico(id ksuid.KSUID) ([]dtos.HistoricoAgendamentoResponseDTO, error) {
+	// Verificar se o agendamento existe
+	if _, err := s.agendamentoRepository.GetByID(id); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	historico, err := s.agendamentoRepository.GetHistorico(id)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchHistory
+	}
+
+	historicoDTO := []dtos.HistoricoAgendamentoResponseDTO{}
+	for _, registro := range historico {
+		registroDTO := dtos.HistoricoAgendamentoResponseDTO{
+			ID:             registro.ID.String(),
+			StatusAnterior: string(registro.StatusAnterior),
+			StatusNovo:     string(registro.StatusNovo),
+			AtorID:         registro.AtorID.String(),
+			AtorTipo:       string(registro.AtorTipo),
+			Motivo:         registro.Motivo,
+			CreatedAt:      registro.CreatedAt.Format(time.RFC3339),
+		}
+		if registro.DataAnterior != nil && registro.DataNova != nil {
+			registroDTO.DataAnterior = registro.DataAnterior.Format(time.RFC3339)
+			registroDTO.DataNova = registro.DataNova.Format(time.RFC3339)
+		}
+		historicoDTO = append(historicoDTO, registroDTO)
+	}
+	return historicoDTO, nil
+}
+
+// GetAgendaFuncionario lista os agendamentos ativos atribuídos a um funcionário entre as datas de e ate
+// (AAAA-MM-DD, inclusivas, no fuso do petshop). Sem datas, retorna os próximos 7 dias a partir de hoje
+func (s *AgendamentoService) GetAgendaFuncionario(petshopID, funcionarioID ksuid.KSUID, de, ate string) (*dtos.FuncionarioAgendaDTO, error) {
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	funcionario, err := s.funcionarioRepository.GetByID(funcionarioID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrFuncionarioNotFound
+		}
+		return nil, errors.ErrFailedToFetchStaff
+	}
+	if funcionario.PetshopID != petshopID {
+		return nil, errors.ErrFuncionarioNotFromPetshop
+	}
+
+	inicio, fim, err := periodoAgenda(de, ate, petshop.Localizacao())
+	if err != nil {
+		return nil, err
+	}
+
+	agendamentos, err := s.agendamentoRepository.GetByFuncionarioNoPeriodo(funcionarioID, inicio, fim)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchAgendamentos
+	}
+
+	agendamentosDTO := []dtos.AgendamentoResponseDTO{}
+	for i := range agendamentos {
+		nomePet, nomeDono, err := s.nomesParticipantes(&agendamentos[i])
+		if err != nil {
+			continue // Pular este agendamento se não for possível buscar o pet ou o dono
+		}
+
+		agendamentosDTO = append(agendamentosDTO, *s.entityToResponseDTO(&agendamentos[i], nomePet, nomeDono, petshop.Nome))
+	}
+
+	return &dtos.FuncionarioAgendaDTO{
+		FuncionarioID: funcionario.ID.String(),
+		Nome:          funcionario.Nome,
+		De:            inicio.Format("2006-01-02"),
+		Ate:           fim.AddDate(0, 0, -1).Format("2006-01-02"),
+		Agendamentos:  agendamentosDTO,
+	}, nil
+}
+
+// GetAgendaPetshop monta a agenda do petshop entre as datas de e ate (AAAA-MM-DD, inclusivas, no fuso do
+// petshop), dia a dia e agrupada por funcionário. Sem datas, retorna os próximos 7 dias a partir de hoje
+func (s *AgendamentoService) GetAgendaPetshop(petshopID ksuid.KSUID, de, ate string) (*dtos.AgendaPetshopDTO, error) {
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	loc := petshop.Localizacao()
+	inicio, fim, err := periodoAgenda(de, ate, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	agendamentos, err := s.agendamentoRepository.GetAgendaNoPeriodo(petshopID, inicio, fim)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchAgendamentos
+	}
+
+	funcionarios, err := s.funcionarioRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchStaff
+	}
+	nomesFuncionarios := make(map[ksuid.KSUID]string, len(funcionarios))
+	for _, funcionario := range funcionarios {
+		nomesFuncionarios[funcionario.ID] = funcionario.Nome
+	}
+
+	// Um dia por data do período, mesmo sem agendamentos
+	agenda := &dtos.AgendaPetshopDTO{
+		PetshopID: petshopID.String(),
+		De:        inicio.Format("2006-01-02"),
+		Ate:       fim.AddDate(0, 0, -1).Format("2006-01-02"),
+		Dias:      []dtos.AgendaDiaDTO{},
+	}
+	indiceDia := make(map[string]int)
+	for dia := inicio; dia.Before(fim); dia = dia.AddDate(0, 0, 1) {
+		indiceDia[dia.Format("2006-01-02")] = len(agenda.Dias)
+		agenda.Dias = append(agenda.Dias, dtos.AgendaDiaDTO{Data: dia.Format("2006-01-02"), Grupos: []dtos.AgendaGrupoDTO{}})
+	}
+
+	// Pets, donos e clientes avulsos se repetem na agenda; cada um é buscado uma única vez
+	pets := make(map[ksuid.KSUID]*entities.Pet)
+	donos := make(map[ksuid.KSUID]*entities.Dono)
+	clientes := make(map[ksuid.KSUID]*entities.ClienteAvulso)
+	for i := range agendamentos {
+		agendamento := &agendamentos[i]
+
+		item := dtos.AgendaItemDTO{
+			ID:               agendamento.ID.String(),
+			Inicio:           agendamento.DataAgendada.In(loc).Format(time.RFC3339),
+			Fim:              agendamento.DataFim.In(loc).Format(time.RFC3339),
+			Status:           string(agendamento.Status),
+			Servicos:         []string{},
+			Observacoes:      agendamento.Observacoes,
+			RequerRemarcacao: agendamento.RequerRemarcacao,
+		}
+
+		if agendamento.Avulso() && agendamento.ClienteAvulsoID != nil {
+			cliente, ok := clientes[*agendamento.ClienteAvulsoID]
+			if !ok {
+				if cliente, err = s.clienteAvulsoRepository.GetByID(*agendamento.ClienteAvulsoID); err != nil {
+					continue // Pular este agendamento se não for possível buscar o cliente
+				}
+				clientes[cliente.ID] = cliente
+			}
+			item.NomePet = cliente.NomePet
+			item.EspeciePet = cliente.EspeciePet
+			item.NomeDono = cliente.Nome
+			item.ClienteAvulsoID = cliente.ID.String()
+			item.TelefoneCliente = cliente.Telefone
+		} else {
+			pet, ok := pets[agendamento.PetID]
+			if !ok {
+				if pet, err = s.petRepository.GetByID(agendamento.PetID); err != nil {
+					continue // Pular este agendamento se não for possível buscar o pet
+				}
+				pets[agendamento.PetID] = pet
+			}
+
+			dono, ok := donos[agendamento.DonoID]
+			if !ok {
+				if dono, err = s.donoRepository.GetByID(agendamento.DonoID); err != nil {
+					continue // Pular este agendamento se não for possível buscar o dono
+				}
+				donos[agendamento.DonoID] = dono
+			}
+
+			item.PetID = pet.ID.String()
+			item.NomePet = pet.Nome
+			item.EspeciePet = pet.Especie
+			item.DonoID = dono.ID.String()
+			item.NomeDono = dono.Nome
+		}
+		// Com vários pets, cada serviço indica o pet atendido e o nome do pet reúne os nomes de todos
+		if agendamento.QuantidadePets() > 1 {
+			var nomesPets []string
+			petsListados := make(map[ksuid.KSUID]bool)
+			for i, itemAgendamento := range agendamento.Itens {
+				if petID := agendamento.PetDoItem(&agendamento.Itens[i]); !petsListados[petID] {
+					petsListados[petID] = true
+					nomesPets = append(nomesPets, itemAgendamento.NomePet)
+				}
+				item.Servicos = append(item.Servicos, itemAgendamento.NomePet+": "+itemAgendamento.NomeServico)
+			}
+			item.NomePet = strings.Join(nomesPets, ", ")
+		} else {
+			for _, itemAgendamento := range agendamento.Itens {
+				item.Servicos = append(item.Servicos, itemAgendamento.NomeServico)
+			}
+		}
+
+		dia := &agenda.Dias[indiceDia[agendamento.DataAgendada.In(loc).Format("2006-01-02")]]
+		dia.Total++
+		adicionarAoGrupo(dia, agendamento.FuncionarioID, nomesFuncionarios, item)
+	}
+
+	// Grupos de funcionários em ordem alfabética; agendamentos sem funcionário ao final
+	for i := range agenda.Dias {
+		grupos := agenda.Dias[i].Grupos
+		sort.SliceStable(grupos, func(a, b int) bool {
+			if (grupos[a].FuncionarioID == "") != (grupos[b].FuncionarioID == "") {
+				return grupos[b].FuncionarioID == ""
+			}
+			return grupos[a].NomeFuncionario < grupos[b].NomeFuncionario
+		})
+	}
+
+	return agenda, nil
+}
+
+// adicionarAoGrupo inclui o item no grupo do funcionário dentro do dia, criando o grupo se necessário.
+// Funcionários excluídos continuam identificados pelo ID, sem nome
+func adicionarAoGrupo(dia *dtos.AgendaDiaDTO, funcionarioID *ksuid.KSUID, nomes map[ksuid.KSUID]string, item dtos.AgendaItemDTO) {
+	grupo := dtos.AgendaGrupoDTO{}
+	if funcionarioID != nil {
+		grupo.FuncionarioID = funcionarioID.String()
+		grupo.NomeFuncionario = nomes[*funcionarioID]
+	}
+
+	for i := range dia.Grupos {
+		if dia.Grupos[i].FuncionarioID == grupo.FuncionarioID {
+			dia.Grupos[i].Agendamentos = append(dia.Grupos[i].Agendamentos, item)
+			return
+		}
+	}
+	grupo.Agendamentos = []dtos.AgendaItemDTO{item}
+	dia.Grupos = append(dia.Grupos, grupo)
+}
+
+// periodoAgenda converte as datas de e ate (inclusivas) no período [inicio, fim) no fuso informado.
+// Sem de, começa hoje; sem ate, cobre 7 dias. O período é limitado a 31 dias
+func periodoAgenda(de, ate string, loc *time.Location) (time.Time, time.Time, error) {
+	agora := time.Now().In(loc)
+	inicio := time.Date(agora.Year(), agora.Month(), agora.Day(), 0, 0, 0, 0, loc)
+	if de != "" {
+		data, err := time.ParseInLocation("2006-01-02", de, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.ErrInvalidAgendaPeriod
+		}
+		inicio = data
+	}
+
+	fim := inicio.AddDate(0, 0, 7)
+	if ate != "" {
+		data, err := time.ParseInLocation("2006-01-02", ate, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.ErrInvalidAgendaPeriod
+		}
+		fim = data.AddDate(0, 0, 1)
+	}
+
+	if !fim.After(inicio) || fim.After(inicio.AddDate(0, 0, 31)) {
+		return time.Time{}, time.Time{}, errors.ErrInvalidAgendaPeriod
+	}
+	return inicio, fim, nil
+}
+
+// GetDisponibilidade lista os horários de início livres em uma data para o conjunto de serviços informado,
+// considerando o horário de funcionamento do petshop e os agendamentos já existentes
+func (s *AgendamentoService) GetDisponibilidade(petshopID ksuid.KSUID, data string, servicoIDs []string) (*dtos.DisponibilidadeResponseDTO, error) {
+	// Verificar se o petshop existe
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	// A data é interpretada no fuso horário do petshop
+	inicioDia, err := time.ParseInLocation("2006-01-02", data, petshop.Localizacao())
+	if err != nil {
+		return nil, errors.ErrInvalidDate
+	}
+	fimDia := inicioDia.AddDate(0, 0, 1)
+
+	// A duração do atendimento é a soma das durações dos serviços selecionados
+	var duracao time.Duration
+	var servicos []ksuid.KSUID
+	var candidato entities.Agendamento // Atendimento hipotético usado na checagem de recursos físicos
+	for _, servicoIDStr := range servicoIDs {
+		servicoID, err := ksuid.Parse(servicoIDStr)
 		if err != nil {
 			return nil, errors.ErrInvalidID
 		}
 
-		// Verificar se o serviço existe e pertence ao petshop
 		servico, err := s.servicoRepository.GetByID(servicoID)
 		if err != nil {
 			if err == errors.ErrNotFound {
@@ -372,7 +1509,7 @@ func (s *AgendamentoService) Update(id ksuid.KSUID, dto *dtos.AgendamentoUpdateD
 			return nil, errors.ErrFailedToCheckService
 		}
 
-		if servico.PetshopID != agendamento.PetshopID {
+		if servico.PetshopID != petshopID {
 			return nil, errors.ErrServiceNotFromPetshop
 		}
 
@@ -380,76 +1517,541 @@ func (s *AgendamentoService) Update(id ksuid.KSUID, dto *dtos.AgendamentoUpdateD
 			return nil, errors.ErrServiceInactive
 		}
 
-		// Adicionar item ao agendamento
-		itens = append(itens, entities.ItemAgendamento{
-			AgendamentoID: agendamento.ID,
-			ServicoID:     servicoID,
-			NomeServico:   servico.Nome,
-			PrecoPrevisto: itemDTO.PrecoPrevisto,
+		duracao += servico.Duracao()
+		servicos = append(servicos, servicoID)
+		candidato.Itens = append(candidato.Itens, entities.ItemAgendamento{
+			ServicoID:      servicoID,
+			NomeServico:    servico.Nome,
+			PrecoPrevisto:  servico.PrecoAgendamento(),
+			DuracaoMinutos: int(servico.Duracao() / time.Minute),
 		})
+	}
 
-		totalCalculado += itemDTO.PrecoPrevisto
+	horarios, err := s.horarioRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchBusinessHours
 	}
-	// Validar o total previsto
-	if totalCalculado != dto.TotalPrevisto {
-		return nil, errors.ErrTotalPrevistoMismatch
+
+	existentes, err := s.agendamentoRepository.GetAtivosNoPeriodo(petshopID, inicioDia, fimDia)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchAgendamentos
 	}
 
-	// Atualizar itens
-	agendamento.Itens = itens
+	fechamentos, err := s.fechamentoRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchClosures
+	}
 
-	// Salvar no repositório
-	if err := s.agendamentoRepository.Update(agendamento); err != nil {
-		return nil, errors.ErrFailedToUpdateAgendamento
+	funcionarios, err := s.funcionarioRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchStaff
 	}
+	ativos, habilitados := funcionariosHabilitados(funcionarios, servicos)
 
-	// Buscar informações adicionais para o DTO
-	pet, err := s.petRepository.GetByID(agendamento.PetID)
+	recursos, err := s.recursoRepository.GetByPetshopID(petshopID)
 	if err != nil {
-		return nil, errors.ErrFailedToFetchPetInfo
+		return nil, errors.ErrFailedToFetchResources
 	}
 
-	dono, err := s.donoRepository.GetByID(agendamento.DonoID)
+	capacidade := petshop.Capacidade
+	if capacidade < 1 {
+		capacidade = 1
+	}
+
+	// Percorrer cada intervalo de funcionamento do dia em passos fixos
+	agora := time.Now()
+	livres := []string{}
+	for _, horario := range horarios {
+		if horario.DiaSemana != inicioDia.Weekday() {
+			continue
+		}
+
+		abertura := inicioDia.Add(time.Duration(horario.InicioMinutos) * time.Minute)
+		fechamento := inicioDia.Add(time.Duration(horario.FimMinutos) * time.Minute)
+		for inicio := abertura; !inicio.Add(duracao).After(fechamento); inicio = inicio.Add(intervaloDisponibilidade) {
+			if inicio.Before(agora) || fechadoNoPeriodo(fechamentos, inicio, inicio.Add(duracao), petshop.Localizacao()) {
+				continue
+			}
+			if picoSimultaneo(inicio, inicio.Add(duracao), existentes)+1 > capacidade {
+				continue
+			}
+			// Os recursos físicos usados pelos serviços precisam ter unidades livres
+			candidato.DataAgendada = inicio
+			candidato.CalcularDataFim()
+			if excedeRecursos(&candidato, existentes, recursos) {
+				continue
+			}
+			// Com funcionários cadastrados, algum habilitado precisa estar livre
+			if ativos > 0 && escolherFuncionario(habilitados, funcionariosOcupados(inicio, inicio.Add(duracao), existentes)) == nil {
+				continue
+			}
+			livres = append(livres, inicio.Format(time.RFC3339))
+		}
+	}
+
+	return &dtos.DisponibilidadeResponseDTO{
+		PetshopID:      petshopID.String(),
+		Data:           inicioDia.Format("2006-01-02"),
+		DuracaoMinutos: int(duracao / time.Minute),
+		Horarios:       livres,
+		Precificacao:   precificacao(candidato.Itens),
+	}, nil
+}
+
+// validarHorarioFuncionamento verifica se o período [inicio, fim) cabe inteiramente em um dos
+// intervalos de funcionamento do petshop no dia da semana correspondente
+func (s *AgendamentoService) validarHorarioFuncionamento(petshop *entities.Petshop, inicio, fim time.Time) error {
+	horarios, err := s.horarioRepository.GetByPetshopID(petshop.ID)
 	if err != nil {
-		return nil, errors.ErrFailedToFetchDonoInfo
+		return errors.ErrFailedToFetchBusinessHours
 	}
 
-	petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+	// Converter o período para minutos relativos ao início do dia no fuso do petshop
+	inicio = inicio.In(petshop.Localizacao())
+	inicioDia := time.Date(inicio.Year(), inicio.Month(), inicio.Day(), 0, 0, 0, 0, inicio.Location())
+	inicioMinutos := int(inicio.Sub(inicioDia) / time.Minute)
+	fimMinutos := int(fim.Sub(inicioDia) / time.Minute)
+
+	for _, horario := range horarios {
+		if horario.DiaSemana == inicio.Weekday() && horario.Contem(inicioMinutos, fimMinutos) {
+			return nil
+		}
+	}
+	return errors.ErrOutsideBusinessHours
+}
+
+// validarFechamentos verifica se o petshop não possui fechamento cadastrado no período [inicio, fim)
+func (s *AgendamentoService) validarFechamentos(petshop *entities.Petshop, inicio, fim time.Time) error {
+	fechamentos, err := s.fechamentoRepository.GetByPetshopID(petshop.ID)
 	if err != nil {
-		return nil, errors.ErrFailedToFetchPetshopInfo
+		return errors.ErrFailedToFetchClosures
 	}
 
-	// Converter para DTO de resposta
-	return s.entityToResponseDTO(agendamento, pet.Nome, dono.Nome, petshop.Nome), nil
+	if fechadoNoPeriodo(fechamentos, inicio, fim, petshop.Localizacao()) {
+		return errors.ErrPetshopClosed
+	}
+	return nil
+}
+
+// fechadoNoPeriodo verifica se algum dos fechamentos se sobrepõe ao período [inicio, fim)
+func fechadoNoPeriodo(fechamentos []entities.FechamentoPetshop, inicio, fim time.Time, loc *time.Location) bool {
+	for _, fechamento := range fechamentos {
+		if fechamento.SobrepoeA(inicio, fim, loc) {
+			return true
+		}
+	}
+	return false
 }
 
 // Helper para converter entidade Agendamento para DTO de resposta
 func (s *AgendamentoService) entityToResponseDTO(agendamento *entities.Agendamento, nomePet string, nomeDono string, nomePetshop string) *dtos.AgendamentoResponseDTO {
 	// Converter itens
+	var serieID string
+	if agendamento.SerieID != nil {
+		serieID = agendamento.SerieID.String()
+	}
+
 	var itensDTO []dtos.ItemAgendamentoResponseDTO
+	for i, item := range agendamento.Itens {
+		itemDTO := dtos.ItemAgendamentoResponseDTO{
+			ID:             item.ID.String(),
+			ServicoID:      item.ServicoID.String(),
+			NomeServico:    item.NomeServico,
+			NomePet:        item.NomePet,
+			PrecoPrevisto:  item.PrecoPrevisto,
+			DuracaoMinutos: item.DuracaoMinutos,
+			Execucao:       string(item.Execucao),
+			Adicionado:     item.Adicionado,
+			PrecoFinal:     item.PrecoFinal,
+			MotivoExecucao: item.MotivoExecucao,
+		}
+		if itemDTO.Execucao == "" {
+			itemDTO.Execucao = string(entities.ExecucaoPrevista)
+		}
+		if petID := agendamento.PetDoItem(&agendamento.Itens[i]); !petID.IsNil() {
+			itemDTO.PetID = petID.String()
+		}
+		if itemDTO.NomePet == "" {
+			itemDTO.NomePet = nomePet
+		}
+		itensDTO = append(itensDTO, itemDTO)
+	}
+
+	// Com vários pets, o nome do pet na resposta reúne os nomes de todos
+	petsDTO := petsDoAgendamento(itensDTO)
+	if len(petsDTO) > 1 {
+		nomePet = nomesDosPets(petsDTO)
+	}
+
+	var cancelamento *dtos.CancelamentoDTO
+	if agendamento.CanceladoEm != nil {
+		cancelamento = &dtos.CancelamentoDTO{
+			CanceladoEm: agendamento.CanceladoEm.Format(time.RFC3339),
+			NoPrazo:     agendamento.CancelamentoNoPrazo,
+			Taxa:        agendamento.TaxaCancelamento,
+		}
+	}
+
+	response := &dtos.AgendamentoResponseDTO{
+		ID:               agendamento.ID.String(),
+		DonoID:           agendamento.DonoID.String(),
+		NomeDono:         nomeDono,
+		PetID:            agendamento.PetID.String(),
+		NomePet:          nomePet,
+		PetshopID:        agendamento.PetshopID.String(),
+		NomePetshop:      nomePetshop,
+		DataAgendada:     agendamento.DataAgendada.Format(time.RFC3339),
+		DataFim:          agendamento.DataFim.Format(time.RFC3339),
+		Status:           string(agendamento.Status),
+		Observacoes:      agendamento.Observacoes,
+		TotalPrevisto:    agendamento.TotalPrevisto,
+		TotalFinal:       agendamento.TotalFinal(),
+		Itens:            itensDTO,
+		Pets:             petsDTO,
+		Precificacao:     precificacao(agendamento.Itens),
+		RequerRemarcacao: agendamento.RequerRemarcacao,
+		MotivoRemarcacao: agendamento.MotivoRemarcacao,
+		SerieID:          serieID,
+		Cancelamento:     cancelamento,
+		CreatedAt:        agendamento.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        agendamento.UpdatedAt.Format(time.RFC3339),
+	}
+
+	response.ExigeConfirmacaoPresenca = agendamento.ExigeConfirmacaoPresenca
+	response.Remarcacoes = agendamento.Remarcacoes
+	response.Versao = agendamento.Versao
+	response.Origem = string(agendamento.Origem)
+	if agendamento.Avulso() {
+		response.DonoID = ""
+		response.PetID = ""
+	}
+	if agendamento.ClienteAvulsoID != nil {
+		response.ClienteAvulsoID = agendamento.ClienteAvulsoID.String()
+	}
+	if agendamento.FuncionarioID != nil {
+		response.FuncionarioID = agendamento.FuncionarioID.String()
+	}
+	if agendamento.PresencaConfirmadaEm != nil {
+		response.PresencaConfirmadaEm = agendamento.PresencaConfirmadaEm.Format(time.RFC3339)
+	}
+	response.DuracaoPrevistaMinutos = int(agendamento.DataFim.Sub(agendamento.DataAgendada) / time.Minute)
+	if agendamento.CheckInEm != nil {
+		response.CheckInEm = agendamento.CheckInEm.Format(time.RFC3339)
+	}
+	if agendamento.CheckOutEm != nil {
+		response.CheckOutEm = agendamento.CheckOutEm.Format(time.RFC3339)
+	}
+	if duracao, ok := agendamento.DuracaoReal(); ok {
+		minutos := int(duracao / time.Minute)
+		response.DuracaoRealMinutos = &minutos
+	}
+	return response
+}
+
+// petsDoAgendamento agrupa os itens da resposta por pet, na ordem em que aparecem, com os totais de cada pet
+func petsDoAgendamento(itensDTO []dtos.ItemAgendamentoResponseDTO) []dtos.PetAgendamentoResponseDTO {
+	petsDTO := []dtos.PetAgendamentoResponseDTO{}
+	indicePet := make(map[string]int)
+	for _, itemDTO := range itensDTO {
+		i, ok := indicePet[itemDTO.PetID]
+		if !ok {
+			i = len(petsDTO)
+			indicePet[itemDTO.PetID] = i
+			petsDTO = append(petsDTO, dtos.PetAgendamentoResponseDTO{
+				PetID:   itemDTO.PetID,
+				NomePet: itemDTO.NomePet,
+				Itens:   []dtos.ItemAgendamentoResponseDTO{},
+			})
+		}
+		petsDTO[i].Itens = append(petsDTO[i].Itens, itemDTO)
+		petsDTO[i].Total = entities.ArredondarPreco(petsDTO[i].Total + itemDTO.PrecoPrevisto)
+		if itemDTO.Execucao != string(entities.ExecucaoPulada) {
+			precoFinal := itemDTO.PrecoPrevisto
+			if itemDTO.PrecoFinal != nil {
+				precoFinal = *itemDTO.PrecoFinal
+			}
+			petsDTO[i].TotalFinal = entities.ArredondarPreco(petsDTO[i].TotalFinal + precoFinal)
+		}
+	}
+	return petsDTO
+}
+
+// nomesDosPets junta os nomes dos pets do agendamento, separados por vírgula
+func nomesDosPets(petsDTO []dtos.PetAgendamentoResponseDTO) string {
+	nomes := make([]string, 0, len(petsDTO))
+	for _, petDTO := range petsDTO {
+		nomes = append(nomes, petDTO.NomePet)
+	}
+	return strings.Join(nomes, ", ")
+}
+
+// intervaloDisponibilidade é o passo entre horários de início sugeridos na consulta de disponibilidade
+const intervaloDisponibilidade = 15 * time.Minute
+
+// verificarCapacidade retorna a verificação executada pelo repositório dentro da transação,
+// rejeitando o agendamento quando ele excede a capacidade de atendimentos simultâneos do petshop.
+// Cada pet do agendamento ocupa um atendimento
+func verificarCapacidade(agendamento *entities.Agendamento, capacidade int) func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+	if capacidade < 1 {
+		capacidade = 1
+	}
+	return func(sobrepostos []entities.Agendamento, _ []entities.FechamentoPetshop) error {
+		if picoSimultaneo(agendamento.DataAgendada, agendamento.DataFim, sobrepostos)+agendamento.QuantidadePets() > capacidade {
+			return errors.ErrHorarioIndisponivel
+		}
+		return nil
+	}
+}
+
+// verificarFechamentos acrescenta à verificação a checagem dos fechamentos do petshop, lidos pelo repositório
+// com o petshop bloqueado: fechamentos gravados depois sinalizam o agendamento já gravado, pois também bloqueiam o petshop
+func verificarFechamentos(agendamento *entities.Agendamento, petshop *entities.Petshop, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+	return func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+		if fechadoNoPeriodo(fechamentos, agendamento.DataAgendada, agendamento.DataFim, petshop.Localizacao()) {
+			return errors.ErrPetshopClosed
+		}
+		return verificar(sobrepostos, fechamentos)
+	}
+}
+
+// verificarFuncionario acrescenta à verificação de capacidade a checagem de funcionário. Um funcionário já
+// definido no agendamento precisa pertencer ao petshop, estar ativo, realizar todos os serviços e estar livre
+// no período. Sem funcionário definido, o habilitado com menos agendamentos no dia e livre no período é
+// atribuído dentro da transação. Petshops sem funcionários ativos são verificados apenas pela capacidade
+func (s *AgendamentoService) verificarFuncionario(agendamento *entities.Agendamento, petshop *entities.Petshop, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) (func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error, error) {
+	var servicoIDs []ksuid.KSUID
 	for _, item := range agendamento.Itens {
-		itensDTO = append(itensDTO, dtos.ItemAgendamentoResponseDTO{
-			ID:            item.ID.String(),
-			ServicoID:     item.ServicoID.String(),
-			NomeServico:   item.NomeServico,
-			PrecoPrevisto: item.PrecoPrevisto,
-		})
+		servicoIDs = append(servicoIDs, item.ServicoID)
+	}
+
+	if agendamento.FuncionarioID != nil {
+		funcionario, err := s.funcionarioRepository.GetByID(*agendamento.FuncionarioID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrFuncionarioNotFound
+			}
+			return nil, errors.ErrFailedToFetchStaff
+		}
+		if funcionario.PetshopID != petshop.ID {
+			return nil, errors.ErrFuncionarioNotFromPetshop
+		}
+		if !funcionario.Ativo {
+			return nil, errors.ErrFuncionarioInactive
+		}
+		if !funcionario.PodeRealizar(servicoIDs) {
+			return nil, errors.ErrFuncionarioCannotPerform
+		}
+
+		return func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+			if err := verificar(sobrepostos, fechamentos); err != nil {
+				return err
+			}
+			if funcionariosOcupados(agendamento.DataAgendada, agendamento.DataFim, sobrepostos)[funcionario.ID] {
+				return errors.ErrFuncionarioIndisponivel
+			}
+			return nil
+		}, nil
+	}
+
+	funcionarios, err := s.funcionarioRepository.GetByPetshopID(petshop.ID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchStaff
+	}
+	ativos, habilitados := funcionariosHabilitados(funcionarios, servicoIDs)
+	if ativos == 0 {
+		return verificar, nil
+	}
+	if len(habilitados) == 0 {
+		return nil, errors.ErrNoStaffAvailable
 	}
 
-	return &dtos.AgendamentoResponseDTO{
-		ID:            agendamento.ID.String(),
-		DonoID:        agendamento.DonoID.String(),
-		NomeDono:      nomeDono,
-		PetID:         agendamento.PetID.String(),
-		NomePet:       nomePet,
-		PetshopID:     agendamento.PetshopID.String(),
-		NomePetshop:   nomePetshop,
-		DataAgendada:  agendamento.DataAgendada.Format(time.RFC3339),
-		Status:        string(agendamento.Status),
-		Observacoes:   agendamento.Observacoes,
-		TotalPrevisto: agendamento.TotalPrevisto,
-		Itens:         itensDTO,
-		CreatedAt:     agendamento.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     agendamento.UpdatedAt.Format(time.RFC3339),
+	// Ordenar os habilitados pela quantidade de agendamentos no dia, no fuso do petshop
+	inicio := agendamento.DataAgendada.In(petshop.Localizacao())
+	inicioDia := time.Date(inicio.Year(), inicio.Month(), inicio.Day(), 0, 0, 0, 0, inicio.Location())
+	carga, err := s.agendamentoRepository.ContarPorFuncionario(petshop.ID, inicioDia, inicioDia.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, errors.ErrFailedToFetchAgendamentos
+	}
+	sort.SliceStable(habilitados, func(i, j int) bool {
+		return carga[habilitados[i].ID] < carga[habilitados[j].ID]
+	})
+
+	return func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+		if err := verificar(sobrepostos, fechamentos); err != nil {
+			return err
+		}
+		escolhido := escolherFuncionario(habilitados, funcionariosOcupados(agendamento.DataAgendada, agendamento.DataFim, sobrepostos))
+		if escolhido == nil {
+			return errors.ErrNoStaffAvailable
+		}
+		funcionarioID := escolhido.ID
+		agendamento.FuncionarioID = &funcionarioID
+		return nil
+	}, nil
+}
+
+// verificarRecursos acrescenta à verificação a checagem dos recursos físicos do petshop: cada recurso
+// consumido pelos serviços do agendamento precisa ter uma unidade livre durante todo o uso
+func (s *AgendamentoService) verificarRecursos(agendamento *entities.Agendamento, petshop *entities.Petshop, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) (func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error, error) {
+	recursos, err := s.recursoRepository.GetByPetshopID(petshop.ID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchResources
+	}
+	if len(recursos) == 0 {
+		return verificar, nil
+	}
+
+	return func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+		if err := verificar(sobrepostos, fechamentos); err != nil {
+			return err
+		}
+		if excedeRecursos(agendamento, sobrepostos, recursos) {
+			return errors.ErrRecursoIndisponivel
+		}
+		return nil
+	}, nil
+}
+
+// excedeRecursos verifica se o agendamento, somado aos agendamentos sobrepostos, precisa de mais
+// unidades de algum recurso do que o petshop possui
+func excedeRecursos(agendamento *entities.Agendamento, sobrepostos []entities.Agendamento, recursos []entities.Recurso) bool {
+	quantidades := make(map[ksuid.KSUID]int)
+	consumos := make(map[ksuid.KSUID][]entities.ConsumoRecurso)
+	for _, recurso := range recursos {
+		quantidades[recurso.ID] = recurso.Quantidade
+		for _, consumo := range recurso.Consumos {
+			consumos[consumo.ServicoID] = append(consumos[consumo.ServicoID], consumo)
+		}
+	}
+
+	pedidos := usosRecursos(agendamento, consumos)
+	if len(pedidos) == 0 {
+		return false
+	}
+
+	ocupados := make(map[ksuid.KSUID][]entities.Agendamento)
+	for i := range sobrepostos {
+		for recursoID, usos := range usosRecursos(&sobrepostos[i], consumos) {
+			ocupados[recursoID] = append(ocupados[recursoID], usos...)
+		}
+	}
+
+	for recursoID, usos := range pedidos {
+		for _, uso := range usos {
+			if picoSimultaneo(uso.DataAgendada, uso.DataFim, ocupados[recursoID])+1 > quantidades[recursoID] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usosRecursos retorna, para cada recurso, os períodos em que o agendamento ocupa uma unidade dele.
+// Cada período é representado como um agendamento com apenas DataAgendada e DataFim preenchidos
+func usosRecursos(agendamento *entities.Agendamento, consumos map[ksuid.KSUID][]entities.ConsumoRecurso) map[ksuid.KSUID][]entities.Agendamento {
+	usos := make(map[ksuid.KSUID][]entities.Agendamento)
+	for _, item := range agendamento.Itens {
+		inicio := agendamento.DataAgendada.Add(time.Duration(item.InicioMinutos) * time.Minute)
+		duracaoServico := time.Duration(item.DuracaoMinutos) * time.Minute
+		for i := range consumos[item.ServicoID] {
+			consumo := &consumos[item.ServicoID][i]
+			usos[consumo.RecursoID] = append(usos[consumo.RecursoID], entities.Agendamento{
+				DataAgendada: inicio,
+				DataFim:      inicio.Add(consumo.Duracao(duracaoServico)),
+			})
+		}
+	}
+	return usos
+}
+
+// funcionariosHabilitados retorna quantos funcionários estão ativos e quais deles realizam todos os serviços
+func funcionariosHabilitados(funcionarios []entities.Funcionario, servicoIDs []ksuid.KSUID) (int, []entities.Funcionario) {
+	ativos := 0
+	var habilitados []entities.Funcionario
+	for _, funcionario := range funcionarios {
+		if !funcionario.Ativo {
+			continue
+		}
+		ativos++
+		if funcionario.PodeRealizar(servicoIDs) {
+			habilitados = append(habilitados, funcionario)
+		}
+	}
+	return ativos, habilitados
+}
+
+// funcionariosOcupados retorna os funcionários com agendamentos que se sobrepõem ao período [inicio, fim)
+func funcionariosOcupados(inicio, fim time.Time, agendamentos []entities.Agendamento) map[ksuid.KSUID]bool {
+	ocupados := make(map[ksuid.KSUID]bool)
+	for _, agendamento := range agendamentos {
+		if agendamento.FuncionarioID != nil && agendamento.DataAgendada.Before(fim) && agendamento.DataFim.After(inicio) {
+			ocupados[*agendamento.FuncionarioID] = true
+		}
+	}
+	return ocupados
+}
+
+// escolherFuncionario retorna o primeiro funcionário, na ordem informada, que não está ocupado
+func escolherFuncionario(candidatos []entities.Funcionario, ocupados map[ksuid.KSUID]bool) *entities.Funcionario {
+	for i := range candidatos {
+		if !ocupados[candidatos[i].ID] {
+			return &candidatos[i]
+		}
+	}
+	return nil
+}
+
+// ignorarReserva envolve uma verificação de capacidade desconsiderando a reserva informada
+func ignorarReserva(reservaID ksuid.KSUID, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+	return func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error {
+		restantes := make([]entities.Agendamento, 0, len(sobrepostos))
+		for _, sobreposto := range sobrepostos {
+			if sobreposto.ID != reservaID {
+				restantes = append(restantes, sobreposto)
+			}
+		}
+		return verificar(restantes, fechamentos)
+	}
+}
+
+// picoSimultaneo calcula o maior número de atendimentos em andamento ao mesmo tempo
+// dentro do período [inicio, fim). Agendamentos com vários pets contam um atendimento por pet
+func picoSimultaneo(inicio, fim time.Time, agendamentos []entities.Agendamento) int {
+	type evento struct {
+		instante time.Time
+		delta    int
+	}
+
+	eventos := make([]evento, 0, len(agendamentos)*2)
+	for _, agendamento := range agendamentos {
+		ini, f := agendamento.DataAgendada, agendamento.DataFim
+		if ini.Before(inicio) {
+			ini = inicio
+		}
+		if f.After(fim) {
+			f = fim
+		}
+		if !ini.Before(f) {
+			continue
+		}
+		pets := agendamento.QuantidadePets()
+		eventos = append(eventos, evento{ini, pets}, evento{f, -pets})
+	}
+
+	// Em instantes iguais, términos são processados antes de inícios
+	sort.Slice(eventos, func(i, j int) bool {
+		if eventos[i].instante.Equal(eventos[j].instante) {
+			return eventos[i].delta < eventos[j].delta
+		}
+		return eventos[i].instante.Before(eventos[j].instante)
+	})
+
+	atual, pico := 0, 0
+	for _, e := range eventos {
+		atual += e.delta
+		if atual > pico {
+			pico = atual
+		}
 	}
+	return pico
 }
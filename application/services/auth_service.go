@@ -85,7 +85,7 @@ func (s *AuthService) AuthenticatePetshop(email, password string) (*dtos.Petshop
 func (s *AuthService) RegisterDono(dto *dtos.DonoRegisterDTO) (*dtos.DonoResponseDTO, error) {
 	// Verificar duplicidade por email
 	existing, err := s.donoRepository.GetByEmail(dto.Email)
-	if err != nil {
+	if err != nil && err != errors.ErrNotFound {
 		return nil, errors.ErrFailedToCheckDono
 	}
 	if existing != nil {
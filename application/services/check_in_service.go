@@ -0,0 +1,171 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// GetTicket emite o ticket de check-in de um agendamento confirmado para o dono ou o petshop do agendamento.
+// O ticket identifica o agendamento e é assinado pelo servidor; remarcar o agendamento invalida os tickets
+// emitidos para a data anterior
+func (s *AgendamentoService) GetTicket(id ksuid.KSUID, ator entities.Ator) (*dtos.TicketAgendamentoDTO, error) {
+	agendamento, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	donoDoAgendamento := ator.Tipo == entities.AtorDono && agendamento.DonoID == ator.ID
+	petshopDoAgendamento := ator.Tipo == entities.AtorPetshop && agendamento.PetshopID == ator.ID
+	if !donoDoAgendamento && !petshopDoAgendamento {
+		return nil, errors.ErrTicketForbidden
+	}
+
+	if agendamento.Status != entities.StatusConfirmado {
+		return nil, errors.ErrTicketNotAvailable
+	}
+
+	petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	return &dtos.TicketAgendamentoDTO{
+		AgendamentoID: agendamento.ID.String(),
+		Ticket:        agendamento.ID.String() + "." + s.assinaturaTicket(agendamento),
+		DataAgendada:  agendamento.DataAgendada.Format(time.RFC3339),
+		NomePetshop:   petshop.Nome,
+	}, nil
+}
+
+// CheckIn registra a chegada a partir do ticket lido pelo petshop. O ticket precisa ter assinatura válida e ser
+// de um agendamento confirmado do próprio petshop, no dia do agendamento, ainda sem check-in
+func (s *AgendamentoService) CheckIn(petshopID ksuid.KSUID, dto *dtos.CheckInDTO) (*dtos.AgendamentoResponseDTO, error) {
+	agendamento, err := s.agendamentoDoTicket(dto.Ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	if agendamento.PetshopID != petshopID {
+		return nil, errors.ErrTicketNotFromPetshop
+	}
+
+	if agendamento.Status != entities.StatusConfirmado {
+		return nil, errors.ErrCheckInNotAllowed
+	}
+
+	if agendamento.CheckInEm != nil {
+		return nil, errors.ErrAlreadyCheckedIn
+	}
+
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	// O dia é o do calendário do petshop
+	agora := time.Now()
+	loc := petshop.Localizacao()
+	if agora.In(loc).Format("2006-01-02") != agendamento.DataAgendada.In(loc).Format("2006-01-02") {
+		return nil, errors.ErrCheckInOutsideWindow
+	}
+
+	if err := s.agendamentoRepository.RegistrarCheckIn(agendamento.ID, agora); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrAlreadyCheckedIn
+		}
+		return nil, errors.ErrFailedToCheckIn
+	}
+
+	return s.GetByID(agendamento.ID)
+}
+
+// CheckOut registra a saída do pet, pelo petshop do agendamento, depois do check-in. O agendamento precisa
+// continuar confirmado ou já ter sido concluído
+func (s *AgendamentoService) CheckOut(id ksuid.KSUID, ator entities.Ator) (*dtos.AgendamentoResponseDTO, error) {
+	if ator.Tipo != entities.AtorPetshop {
+		return nil, errors.ErrCheckInForbidden
+	}
+
+	agendamento, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	if agendamento.PetshopID != ator.ID {
+		return nil, errors.ErrCheckInForbidden
+	}
+
+	if !agendamento.Status.PermiteCheckOut() {
+		return nil, errors.ErrCheckOutNotAllowed
+	}
+
+	if agendamento.CheckInEm == nil {
+		return nil, errors.ErrNotCheckedIn
+	}
+
+	if agendamento.CheckOutEm != nil {
+		return nil, errors.ErrAlreadyCheckedOut
+	}
+
+	if err := s.agendamentoRepository.RegistrarCheckOut(id, time.Now()); err != nil {
+		if err == errors.ErrNotFound {
+			// O agendamento foi cancelado ou recebeu o check-out desde a leitura
+			return nil, errors.ErrCheckOutNotAllowed
+		}
+		return nil, errors.ErrFailedToCheckIn
+	}
+
+	return s.GetByID(id)
+}
+
+// agendamentoDoTicket busca o agendamento identificado no ticket, no formato "<id>.<assinatura>", e confere
+// a assinatura. Tickets de agendamentos inexistentes são tratados como inválidos
+func (s *AgendamentoService) agendamentoDoTicket(ticket string) (*entities.Agendamento, error) {
+	partes := strings.Split(strings.TrimSpace(ticket), ".")
+	if len(partes) != 2 {
+		return nil, errors.ErrInvalidTicket
+	}
+
+	id, err := ksuid.Parse(partes[0])
+	if err != nil {
+		return nil, errors.ErrInvalidTicket
+	}
+
+	agendamento, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrInvalidTicket
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	if !hmac.Equal([]byte(partes[1]), []byte(s.assinaturaTicket(agendamento))) {
+		return nil, errors.ErrInvalidTicket
+	}
+	return agendamento, nil
+}
+
+// assinaturaTicket assina o ID e a data do agendamento com o segredo dos tickets (HMAC-SHA256)
+func (s *AgendamentoService) assinaturaTicket(agendamento *entities.Agendamento) string {
+	mac := hmac.New(sha256.New, s.segredoTicket)
+	fmt.Fprintf(mac, "%s:%d", agendamento.ID, agendamento.DataAgendada.Unix())
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// VincularClienteAvulso vincula o cliente avulso do petshop ao dono cadastrado, a pedido do próprio petshop.
+// Como os contatos informados no cadastro do dono não são verificados, o vínculo nunca é automático: o petshop
+// confirma que conhece a pessoa, e o dono precisa ter o mesmo email ou telefone do cliente
+func (s *AgendamentoService) VincularClienteAvulso(petshopID, clienteID ksuid.KSUID, dto *dtos.ClienteAvulsoVincularDTO) (*dtos.ClienteAvulsoVinculoDTO, error) {
+	donoID, err := ksuid.Parse(dto.DonoID)
+	if err != nil {
+		return nil, errors.ErrInvalidID
+	}
+
+	cliente, err := s.clienteAvulsoRepository.GetByID(clienteID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToFetchGuest
+	}
+
+	// Clientes de outros petshops não são expostos
+	if cliente.PetshopID != petshopID {
+		return nil, errors.ErrNotFound
+	}
+
+	if cliente.DonoID != nil {
+		return nil, errors.ErrGuestAlreadyLinked
+	}
+
+	dono, err := s.donoRepository.GetByID(donoID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrDonoNotFound
+		}
+		return nil, errors.ErrFailedToCheckDono
+	}
+
+	if !contatoConfere(cliente, dono) {
+		return nil, errors.ErrGuestContactMismatch
+	}
+
+	agendamentos, err := s.clienteAvulsoRepository.VincularAoDono(cliente, dono)
+	if err != nil {
+		if err == errors.ErrGuestAlreadyLinked {
+			return nil, err
+		}
+		return nil, errors.ErrFailedToLinkGuest
+	}
+
+	return &dtos.ClienteAvulsoVinculoDTO{
+		ClienteAvulsoID: cliente.ID.String(),
+		DonoID:          dono.ID.String(),
+		PetID:           cliente.PetID.String(),
+		Agendamentos:    agendamentos,
+		VinculadoEm:     cliente.VinculadoEm.Format(time.RFC3339),
+	}, nil
+}
+
+// contatoConfere verifica se o dono tem o email ou o telefone do cliente avulso, ignorando os vazios
+func contatoConfere(cliente *entities.ClienteAvulso, dono *entities.Dono) bool {
+	if cliente.Email != "" && cliente.Email == entities.NormalizarEmail(dono.Email) {
+		return true
+	}
+	return cliente.Telefone != "" && cliente.Telefone == entities.NormalizarTelefone(dono.Telefone)
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/interfaces/repositories"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// horizonteFechamentosRecorrentes define até quando as ocorrências de um fechamento recorrente
+// são consideradas ao sinalizar agendamentos existentes
+const horizonteFechamentosRecorrentes = 2 * 365 * 24 * time.Hour
+
+// FechamentoService fornece métodos para gerenciar o calendário de fechamentos dos petshops
+type FechamentoService struct {
+	fechamentoRepository repositories.FechamentoRepository
+	petshopRepository    repositories.PetshopRepository
+}
+
+// NewFechamentoService cria uma nova instância de FechamentoService
+func NewFechamentoService(fechamentoRepo repositories.FechamentoRepository, petshopRepo repositories.PetshopRepository) *FechamentoService {
+	return &FechamentoService{
+		fechamentoRepository: fechamentoRepo,
+		petshopRepository:    petshopRepo,
+	}
+}
+
+// Create cadastra um fechamento e sinaliza os agendamentos pendentes ou confirmados atingidos por ele
+func (s *FechamentoService) Create(petshopID ksuid.KSUID, dto *dtos.FechamentoCreateDTO) (*dtos.FechamentoResponseDTO, error) {
+	// Verificar se o petshop existe
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	fechamento := &entities.FechamentoPetshop{
+		PetshopID:       petshopID,
+		DiaInteiro:      dto.DiaInteiro,
+		RecorrenteAnual: dto.RecorrenteAnual,
+		Motivo:          dto.Motivo,
+	}
+
+	if dto.DiaInteiro {
+		// Datas interpretadas no fuso do petshop, com a data final inclusiva
+		loc := petshop.Localizacao()
+		inicio, err := time.ParseInLocation("2006-01-02", dto.DataInicio, loc)
+		if err != nil {
+			return nil, errors.ErrInvalidDate
+		}
+		fim, err := time.ParseInLocation("2006-01-02", dto.DataFim, loc)
+		if err != nil {
+			return nil, errors.ErrInvalidDate
+		}
+		fechamento.Inicio = inicio
+		fechamento.Fim = fim.AddDate(0, 0, 1)
+	} else {
+		inicio, err := time.Parse(time.RFC3339, dto.DataInicio)
+		if err != nil {
+			return nil, errors.ErrInvalidDate
+		}
+		fim, err := time.Parse(time.RFC3339, dto.DataFim)
+		if err != nil {
+			return nil, errors.ErrInvalidDate
+		}
+		fechamento.Inicio = inicio
+		fechamento.Fim = fim
+	}
+
+	if !fechamento.Inicio.Before(fechamento.Fim) {
+		return nil, errors.ErrInvalidClosurePeriod
+	}
+
+	// Recorrência anual só faz sentido para dias inteiros que não se sobreponham ao ano seguinte
+	if dto.RecorrenteAnual && (!dto.DiaInteiro || fechamento.Fim.After(fechamento.Inicio.AddDate(1, 0, 0))) {
+		return nil, errors.ErrRecurringClosureMustBeFullDay
+	}
+
+	// Ocorrências futuras usadas para sinalizar os agendamentos já marcados
+	agora := time.Now()
+	ocorrencias := fechamento.OcorrenciasEntre(agora, agora.Add(horizonteFechamentosRecorrentes), petshop.Localizacao())
+
+	afetados, err := s.fechamentoRepository.Create(fechamento, ocorrencias)
+	if err != nil {
+		return nil, errors.ErrFailedToCreateClosure
+	}
+
+	response := s.entityToResponseDTO(fechamento)
+	for _, id := range afetados {
+		response.AgendamentosAfetados = append(response.AgendamentosAfetados, id.String())
+	}
+	return response, nil
+}
+
+// GetByPetshopID lista os fechamentos cadastrados por um petshop
+func (s *FechamentoService) GetByPetshopID(petshopID ksuid.KSUID) ([]dtos.FechamentoResponseDTO, error) {
+	// Verificar se o petshop existe
+	_, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	fechamentos, err := s.fechamentoRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchClosures
+	}
+
+	fechamentoDTOs := []dtos.FechamentoResponseDTO{}
+	for _, fechamento := range fechamentos {
+		fechamentoDTOs = append(fechamentoDTOs, *s.entityToResponseDTO(&fechamento))
+	}
+	return fechamentoDTOs, nil
+}
+
+// Delete remove um fechamento do petshop. Agendamentos já sinalizados continuam sinalizados
+func (s *FechamentoService) Delete(petshopID, fechamentoID ksuid.KSUID) error {
+	fechamento, err := s.fechamentoRepository.GetByID(fechamentoID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrClosureNotFound
+		}
+		return errors.ErrFailedToFetchClosures
+	}
+
+	if fechamento.PetshopID != petshopID {
+		return errors.ErrClosureNotFromPetshop
+	}
+
+	if err := s.fechamentoRepository.Delete(fechamentoID); err != nil {
+		return errors.ErrFailedToDeleteClosure
+	}
+	return nil
+}
+
+// Helper para converter entidade FechamentoPetshop para DTO de resposta
+func (s *FechamentoService) entityToResponseDTO(fechamento *entities.FechamentoPetshop) *dtos.FechamentoResponseDTO {
+	return &dtos.FechamentoResponseDTO{
+		ID:              fechamento.ID.String(),
+		PetshopID:       fechamento.PetshopID.String(),
+		Inicio:          fechamento.Inicio.Format(time.RFC3339),
+		Fim:             fechamento.Fim.Format(time.RFC3339),
+		DiaInteiro:      fechamento.DiaInteiro,
+		RecorrenteAnual: fechamento.RecorrenteAnual,
+		Motivo:          fechamento.Motivo,
+		CreatedAt:       fechamento.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/interfaces/repositories"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// FuncionarioService fornece métodos para gerenciar os funcionários dos petshops
+type FuncionarioService struct {
+	funcionarioRepository repositories.FuncionarioRepository
+	petshopRepository     repositories.PetshopRepository
+	servicoRepository     repositories.ServicoRepository
+}
+
+// NewFuncionarioService cria uma nova instância de FuncionarioService
+func NewFuncionarioService(
+	funcionarioRepo repositories.FuncionarioRepository,
+	petshopRepo repositories.PetshopRepository,
+	servicoRepo repositories.ServicoRepository,
+) *FuncionarioService {
+	return &FuncionarioService{
+		funcionarioRepository: funcionarioRepo,
+		petshopRepository:     petshopRepo,
+		servicoRepository:     servicoRepo,
+	}
+}
+
+// Create cadastra um funcionário no petshop com os serviços que ele realiza
+func (s *FuncionarioService) Create(petshopID ksuid.KSUID, dto *dtos.FuncionarioCreateDTO) (*dtos.FuncionarioResponseDTO, error) {
+	// Verificar se o petshop existe
+	if _, err := s.petshopRepository.GetByID(petshopID); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	servicos, err := s.buscarServicos(petshopID, dto.ServicoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	funcionario := &entities.Funcionario{
+		PetshopID: petshopID,
+		Nome:      dto.Nome,
+		Ativo:     true,
+		Servicos:  servicos,
+	}
+
+	if err := s.funcionarioRepository.Create(funcionario); err != nil {
+		return nil, errors.ErrFailedToCreateStaff
+	}
+
+	return s.entityToResponseDTO(funcionario), nil
+}
+
+// GetByPetshopID lista os funcionários de um petshop
+func (s *FuncionarioService) GetByPetshopID(petshopID ksuid.KSUID) ([]dtos.FuncionarioResponseDTO, error) {
+	// Verificar se o petshop existe
+	if _, err := s.petshopRepository.GetByID(petshopID); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	funcionarios, err := s.funcionarioRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchStaff
+	}
+
+	funcionarioDTOs := []dtos.FuncionarioResponseDTO{}
+	for i := range funcionarios {
+		funcionarioDTOs = append(funcionarioDTOs, *s.entityToResponseDTO(&funcionarios[i]))
+	}
+	return funcionarioDTOs, nil
+}
+
+// Update atualiza os dados e os serviços de um funcionário do petshop.
+// Agendamentos já atribuídos ao funcionário não são alterados
+func (s *FuncionarioService) Update(petshopID, funcionarioID ksuid.KSUID, dto *dtos.FuncionarioUpdateDTO) (*dtos.FuncionarioResponseDTO, error) {
+	funcionario, err := s.buscarDoPetshop(petshopID, funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	servicos, err := s.buscarServicos(petshopID, dto.ServicoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	funcionario.Nome = dto.Nome
+	if dto.Ativo != nil {
+		funcionario.Ativo = *dto.Ativo
+	}
+	funcionario.Servicos = servicos
+
+	if err := s.funcionarioRepository.Update(funcionario); err != nil {
+		return nil, errors.ErrFailedToUpdateStaff
+	}
+
+	return s.entityToResponseDTO(funcionario), nil
+}
+
+// Delete remove um funcionário do petshop. Agendamentos já atribuídos a ele mantêm a atribuição
+func (s *FuncionarioService) Delete(petshopID, funcionarioID ksuid.KSUID) error {
+	if _, err := s.buscarDoPetshop(petshopID, funcionarioID); err != nil {
+		return err
+	}
+
+	if err := s.funcionarioRepository.Delete(funcionarioID); err != nil {
+		return errors.ErrFailedToDeleteStaff
+	}
+	return nil
+}
+
+// buscarDoPetshop busca o funcionário garantindo que ele pertence ao petshop
+func (s *FuncionarioService) buscarDoPetshop(petshopID, funcionarioID ksuid.KSUID) (*entities.Funcionario, error) {
+	funcionario, err := s.funcionarioRepository.GetByID(funcionarioID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrFuncionarioNotFound
+		}
+		return nil, errors.ErrFailedToFetchStaff
+	}
+
+	if funcionario.PetshopID != petshopID {
+		return nil, errors.ErrFuncionarioNotFromPetshop
+	}
+	return funcionario, nil
+}
+
+// buscarServicos valida que cada serviço informado existe e pertence ao petshop
+func (s *FuncionarioService) buscarServicos(petshopID ksuid.KSUID, servicoIDs []string) ([]entities.Servico, error) {
+	var servicos []entities.Servico
+	vistos := make(map[ksuid.KSUID]bool)
+	for _, servicoIDStr := range servicoIDs {
+		servicoID, err := ksuid.Parse(servicoIDStr)
+		if err != nil {
+			return nil, errors.ErrInvalidID
+		}
+		if vistos[servicoID] {
+			continue
+		}
+		vistos[servicoID] = true
+
+		servico, err := s.servicoRepository.GetByID(servicoID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return nil, errors.ErrServiceNotFound
+			}
+			return nil, errors.ErrFailedToCheckService
+		}
+
+		if servico.PetshopID != petshopID {
+			return nil, errors.ErrServiceNotFromPetshop
+		}
+		servicos = append(servicos, *servico)
+	}
+	return servicos, nil
+}
+
+// Helper para converter entidade Funcionario para DTO de resposta
+func (s *FuncionarioService) entityToResponseDTO(funcionario *entities.Funcionario) *dtos.FuncionarioResponseDTO {
+	servicosDTO := []dtos.FuncionarioServicoDTO{}
+	for _, servico := range funcionario.Servicos {
+		servicosDTO = append(servicosDTO, dtos.FuncionarioServicoDTO{
+			ID:   servico.ID.String(),
+			Nome: servico.Nome,
+		})
+	}
+
+	return &dtos.FuncionarioResponseDTO{
+		ID:        funcionario.ID.String(),
+		PetshopID: funcionario.PetshopID.String(),
+		Nome:      funcionario.Nome,
+		Ativo:     funcionario.Ativo,
+		Servicos:  servicosDTO,
+		CreatedAt: funcionario.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/interfaces/repositories"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// HorarioFuncionamentoService fornece métodos para gerenciar o horário de funcionamento dos petshops
+type HorarioFuncionamentoService struct {
+	horarioRepository repositories.HorarioFuncionamentoRepository
+	petshopRepository repositories.PetshopRepository
+}
+
+// NewHorarioFuncionamentoService cria uma nova instância de HorarioFuncionamentoService
+func NewHorarioFuncionamentoService(horarioRepo repositories.HorarioFuncionamentoRepository, petshopRepo repositories.PetshopRepository) *HorarioFuncionamentoService {
+	return &HorarioFuncionamentoService{
+		horarioRepository: horarioRepo,
+		petshopRepository: petshopRepo,
+	}
+}
+
+// GetByPetshopID retorna a grade semanal de funcionamento de um petshop
+func (s *HorarioFuncionamentoService) GetByPetshopID(petshopID ksuid.KSUID) (*dtos.HorarioFuncionamentoResponseDTO, error) {
+	// Verificar se o petshop existe
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	horarios, err := s.horarioRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchBusinessHours
+	}
+
+	return s.entitiesToResponseDTO(petshop, horarios), nil
+}
+
+// Update substitui a grade semanal de funcionamento de um petshop
+func (s *HorarioFuncionamentoService) Update(petshopID ksuid.KSUID, dto *dtos.HorarioFuncionamentoUpdateDTO) (*dtos.HorarioFuncionamentoResponseDTO, error) {
+	// Verificar se o petshop existe
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	// Converter os intervalos, agrupando por dia (o mesmo dia pode aparecer mais de uma vez)
+	porDia := make(map[time.Weekday][]entities.HorarioFuncionamento)
+	for _, diaDTO := range dto.Dias {
+		dia := time.Weekday(diaDTO.DiaSemana)
+		for _, intervaloDTO := range diaDTO.Intervalos {
+			inicio, err := parseHoraMinuto(intervaloDTO.Inicio)
+			if err != nil {
+				return nil, err
+			}
+			fim, err := parseHoraMinuto(intervaloDTO.Fim)
+			if err != nil {
+				return nil, err
+			}
+			if inicio >= fim {
+				return nil, errors.ErrInvalidBusinessHours
+			}
+
+			porDia[dia] = append(porDia[dia], entities.HorarioFuncionamento{
+				PetshopID:     petshopID,
+				DiaSemana:     dia,
+				InicioMinutos: inicio,
+				FimMinutos:    fim,
+			})
+		}
+	}
+
+	// Validar que os intervalos de um mesmo dia não se sobrepõem
+	var horarios []entities.HorarioFuncionamento
+	for _, intervalos := range porDia {
+		sort.Slice(intervalos, func(i, j int) bool {
+			return intervalos[i].InicioMinutos < intervalos[j].InicioMinutos
+		})
+		for i := 1; i < len(intervalos); i++ {
+			if intervalos[i].InicioMinutos < intervalos[i-1].FimMinutos {
+				return nil, errors.ErrOverlappingBusinessHours
+			}
+		}
+		horarios = append(horarios, intervalos...)
+	}
+
+	// Salvar no repositório
+	if err := s.horarioRepository.ReplaceByPetshopID(petshopID, horarios); err != nil {
+		return nil, errors.ErrFailedToUpdateBusinessHours
+	}
+
+	return s.entitiesToResponseDTO(petshop, horarios), nil
+}
+
+// Helper para converter os intervalos de funcionamento para DTO, agrupados e ordenados por dia
+func (s *HorarioFuncionamentoService) entitiesToResponseDTO(petshop *entities.Petshop, horarios []entities.HorarioFuncionamento) *dtos.HorarioFuncionamentoResponseDTO {
+	sort.Slice(horarios, func(i, j int) bool {
+		if horarios[i].DiaSemana != horarios[j].DiaSemana {
+			return horarios[i].DiaSemana < horarios[j].DiaSemana
+		}
+		return horarios[i].InicioMinutos < horarios[j].InicioMinutos
+	})
+
+	dias := []dtos.HorarioDiaDTO{}
+	for _, horario := range horarios {
+		if len(dias) == 0 || dias[len(dias)-1].DiaSemana != int(horario.DiaSemana) {
+			dias = append(dias, dtos.HorarioDiaDTO{DiaSemana: int(horario.DiaSemana)})
+		}
+		dia := &dias[len(dias)-1]
+		dia.Intervalos = append(dia.Intervalos, dtos.IntervaloHorarioDTO{
+			Inicio: formatHoraMinuto(horario.InicioMinutos),
+			Fim:    formatHoraMinuto(horario.FimMinutos),
+		})
+	}
+
+	return &dtos.HorarioFuncionamentoResponseDTO{
+		PetshopID:   petshop.ID.String(),
+		FusoHorario: petshop.Localizacao().String(),
+		Dias:        dias,
+	}
+}
+
+// parseHoraMinuto converte um horário HH:MM em minutos desde a meia-noite (aceita 24:00)
+func parseHoraMinuto(valor string) (int, error) {
+	var hora, minuto int
+	if _, err := fmt.Sscanf(valor, "%d:%d", &hora, &minuto); err != nil || len(valor) != 5 {
+		return 0, errors.ErrInvalidTimeFormat
+	}
+	if hora < 0 || minuto < 0 || minuto > 59 || hora*60+minuto > entities.MinutosPorDia {
+		return 0, errors.ErrInvalidTimeFormat
+	}
+	return hora*60 + minuto, nil
+}
+
+// formatHoraMinuto converte minutos desde a meia-noite para o formato HH:MM
+func formatHoraMinuto(minutos int) string {
+	return fmt.Sprintf("%02d:%02d", minutos/60, minutos%60)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/interfaces/repositories"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+)
+
+// TamanhoMaximoChaveIdempotencia é o maior valor aceito no cabeçalho Idempotency-Key
+const TamanhoMaximoChaveIdempotencia = 255
+
+// IdempotenciaService controla as chaves de idempotência das requisições de criação
+type IdempotenciaService struct {
+	idempotenciaRepository repositories.IdempotenciaRepository
+	janela                 time.Duration
+}
+
+// NewIdempotenciaService cria uma nova instância de IdempotenciaService. A resposta de cada chave
+// é reaproveitada durante a janela informada
+func NewIdempotenciaService(idempotenciaRepo repositories.IdempotenciaRepository, janela time.Duration) *IdempotenciaService {
+	return &IdempotenciaService{
+		idempotenciaRepository: idempotenciaRepo,
+		janela:                 janela,
+	}
+}
+
+// Iniciar reserva a chave do usuário para a requisição. Se a chave já foi usada na janela com a mesma
+// rota e o mesmo corpo, retorna o registro existente com repetir = true para que a resposta guardada
+// seja reenviada. Chaves reutilizadas em outra requisição retornam ErrIdempotencyKeyReused
+func (s *IdempotenciaService) Iniciar(usuarioID, chave, rota string, corpo []byte) (registro *entities.ChaveIdempotencia, repetir bool, err error) {
+	if chave == "" || len(chave) > TamanhoMaximoChaveIdempotencia {
+		return nil, false, errors.ErrInvalidIdempotencyKey
+	}
+
+	hash := sha256.Sum256(corpo)
+	reserva := &entities.ChaveIdempotencia{
+		UsuarioID: usuarioID,
+		Chave:     chave,
+		Rota:      rota,
+		HashCorpo: hex.EncodeToString(hash[:]),
+		ExpiraEm:  time.Now().Add(s.janela),
+	}
+
+	existente, err := s.idempotenciaRepository.Reservar(reserva)
+	if err != nil {
+		return nil, false, errors.ErrFailedToCheckIdempotencyKey
+	}
+	if existente == nil {
+		return reserva, false, nil
+	}
+
+	if existente.Rota != reserva.Rota || existente.HashCorpo != reserva.HashCorpo {
+		return nil, false, errors.ErrIdempotencyKeyReused
+	}
+	if existente.EmAndamento() {
+		return nil, false, errors.ErrIdempotencyKeyInProgress
+	}
+	return existente, true, nil
+}
+
+// Concluir guarda a resposta da requisição original. Erros internos (5xx) não são guardados:
+// a chave é liberada para que o cliente possa tentar novamente
+func (s *IdempotenciaService) Concluir(registro *entities.ChaveIdempotencia, statusCode int, resposta []byte) error {
+	if statusCode >= 500 {
+		return s.Liberar(registro)
+	}
+	return s.idempotenciaRepository.Concluir(registro.ID, statusCode, resposta)
+}
+
+// Liberar remove a reserva da chave sem guardar resposta
+func (s *IdempotenciaService) Liberar(registro *entities.ChaveIdempotencia) error {
+	return s.idempotenciaRepository.Liberar(registro.ID)
+}
+
+// IniciarLimpeza remove periodicamente, em segundo plano, as chaves cuja janela terminou
+func (s *IdempotenciaService) IniciarLimpeza(intervalo time.Duration) {
+	go func() {
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := s.idempotenciaRepository.RemoverExpiradas(time.Now()); err != nil {
+				log.Printf("Erro ao remover chaves de idempotência expiradas: %v", err)
+			}
+		}
+	}()
+}
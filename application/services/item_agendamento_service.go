@@ -0,0 +1,177 @@
+package services
+
+import (
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// AtualizarExecucaoItem registra se um serviço do agendamento confirmado foi executado ou pulado e o preço
+// cobrado. O total final e o procedimento gerado na conclusão passam a considerar a execução registrada
+func (s *AgendamentoService) AtualizarExecucaoItem(id, itemID ksuid.KSUID, dto *dtos.ItemExecucaoUpdateDTO, ator entities.Ator, versaoEsperada int) (*dtos.AgendamentoResponseDTO, error) {
+	agendamento, err := s.agendamentoEmAtendimento(id, ator, versaoEsperada)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *entities.ItemAgendamento
+	for i := range agendamento.Itens {
+		if agendamento.Itens[i].ID == itemID {
+			item = &agendamento.Itens[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, errors.ErrItemNotFromAgendamento
+	}
+
+	execucao := entities.ExecucaoItem(dto.Execucao)
+	if execucao == entities.ExecucaoPulada && dto.PrecoFinal != nil {
+		return nil, errors.ErrSkippedItemPriced
+	}
+
+	item.Execucao = execucao
+	item.PrecoFinal = nil
+	item.MotivoExecucao = dto.Motivo
+	if dto.PrecoFinal != nil {
+		precoFinal := entities.ArredondarPreco(*dto.PrecoFinal)
+		item.PrecoFinal = &precoFinal
+	}
+
+	if err := s.salvarItem(agendamento, item, nil); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// AdicionarItem inclui no agendamento confirmado um serviço feito no balcão, fora do que foi agendado.
+// O item entra como executado, com o preço atual do serviço ou o informado. Quando o término previsto do
+// agendamento é estendido, o petshop, os recursos e o funcionário precisam estar livres no novo período
+func (s *AgendamentoService) AdicionarItem(id ksuid.KSUID, dto *dtos.ItemAdicionadoCreateDTO, ator entities.Ator, versaoEsperada int) (*dtos.AgendamentoResponseDTO, error) {
+	agendamento, err := s.agendamentoEmAtendimento(id, ator, versaoEsperada)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sem pet_id, o serviço é do único pet do agendamento
+	petID := agendamento.PetID
+	if dto.PetID != "" {
+		if petID, err = ksuid.Parse(dto.PetID); err != nil {
+			return nil, errors.ErrInvalidID
+		}
+	} else if agendamento.QuantidadePets() > 1 {
+		return nil, errors.ErrPetNotInAgendamento
+	}
+
+	nomePet := ""
+	petNoAgendamento := false
+	for i := range agendamento.Itens {
+		if agendamento.PetDoItem(&agendamento.Itens[i]) == petID {
+			petNoAgendamento = true
+			if nomePet == "" {
+				nomePet = agendamento.Itens[i].NomePet
+			}
+		}
+	}
+	if !petNoAgendamento && petID != agendamento.PetID {
+		return nil, errors.ErrPetNotInAgendamento
+	}
+	if nomePet == "" {
+		if nomePet, _, err = s.nomesParticipantes(agendamento); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reaproveitar as validações e o preço dos serviços da criação de agendamentos
+	pet := []petAgendado{{
+		pet:   &entities.Pet{ID: petID, Nome: nomePet},
+		itens: []dtos.ItemAgendamentoCreateDTO{{ServicoID: dto.ServicoID}},
+	}}
+	itens, _, err := s.itensAgendamento(agendamento.PetshopID, pet)
+	if err != nil {
+		return nil, err
+	}
+
+	item := itens[0]
+	item.Execucao = entities.ExecucaoRealizada
+	item.Adicionado = true
+	item.MotivoExecucao = dto.Motivo
+	if dto.PrecoFinal != nil {
+		precoFinal := entities.ArredondarPreco(*dto.PrecoFinal)
+		item.PrecoFinal = &precoFinal
+	}
+
+	// O serviço adicionado é feito depois dos demais
+	dataFimAnterior := agendamento.DataFim
+	agendamento.Itens = append(agendamento.Itens, item)
+	agendamento.CalcularDataFim()
+
+	// A extensão do atendimento não pode invadir fechamentos nem ocupar capacidade, recursos ou o funcionário
+	// já comprometidos com outros agendamentos (o próprio agendamento não conta como conflito)
+	var verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error
+	if agendamento.DataFim.After(dataFimAnterior) {
+		petshop, err := s.petshopRepository.GetByID(agendamento.PetshopID)
+		if err != nil {
+			return nil, errors.ErrFailedToFetchPetshopInfo
+		}
+		verificar = verificarFechamentos(agendamento, petshop, verificarCapacidade(agendamento, petshop.Capacidade))
+		if verificar, err = s.verificarRecursos(agendamento, petshop, verificar); err != nil {
+			return nil, err
+		}
+		if verificar, err = s.verificarFuncionario(agendamento, petshop, verificar); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.salvarItem(agendamento, &agendamento.Itens[len(agendamento.Itens)-1], verificar); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// agendamentoEmAtendimento busca o agendamento cujos itens o petshop quer alterar, verificando que o ator é o
+// petshop do agendamento, que o agendamento está confirmado e, se informada, que a versão é a esperada
+func (s *AgendamentoService) agendamentoEmAtendimento(id ksuid.KSUID, ator entities.Ator, versaoEsperada int) (*entities.Agendamento, error) {
+	if ator.Tipo != entities.AtorPetshop {
+		return nil, errors.ErrItemExecutionForbidden
+	}
+
+	agendamento, err := s.agendamentoRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToCheckAgendamento
+	}
+
+	if agendamento.PetshopID != ator.ID {
+		return nil, errors.ErrItemExecutionForbidden
+	}
+
+	// O cliente editou uma versão que já foi substituída
+	if versaoEsperada != 0 && agendamento.Versao != versaoEsperada {
+		return nil, errors.ErrAgendamentoVersionConflict
+	}
+
+	if agendamento.Status != entities.StatusConfirmado {
+		return nil, errors.ErrItemExecutionNotAllowed
+	}
+	return agendamento, nil
+}
+
+// salvarItem grava o item e converte os erros do repositório
+func (s *AgendamentoService) salvarItem(agendamento *entities.Agendamento, item *entities.ItemAgendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error {
+	if err := s.agendamentoRepository.SalvarItem(agendamento, item, verificar); err != nil {
+		switch err {
+		case errors.ErrAgendamentoVersionConflict, errors.ErrHorarioIndisponivel, errors.ErrRecursoIndisponivel,
+			errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable, errors.ErrPetshopClosed:
+			return err
+		case errors.ErrNotFound:
+			return errors.ErrItemNotFromAgendamento
+		default:
+			return errors.ErrFailedToUpdateItem
+		}
+	}
+	return nil
+}
@@ -0,0 +1,425 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// janelaMaximaListaEspera limita até quando no futuro uma entrada da lista de espera pode aguardar
+const janelaMaximaListaEspera = 60 * 24 * time.Hour
+
+// CreateListaEspera adiciona o dono autenticado à lista de espera do petshop para a janela de datas informada
+func (s *AgendamentoService) CreateListaEspera(dto *dtos.ListaEsperaCreateDTO, ator entities.Ator) (*dtos.ListaEsperaResponseDTO, error) {
+	// O dono da entrada é sempre o autenticado; um dono_id diferente no corpo não é aceito
+	if ator.Tipo != entities.AtorDono || (dto.DonoID != "" && dto.DonoID != ator.ID.String()) {
+		return nil, errors.ErrOnlyDonoJoinsWaitlist
+	}
+
+	janelaInicio, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.JanelaInicio)
+	if err != nil {
+		return nil, errors.ErrInvalidDate
+	}
+	janelaFim, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.JanelaFim)
+	if err != nil {
+		return nil, errors.ErrInvalidDate
+	}
+
+	agora := time.Now()
+	if !janelaFim.After(janelaInicio) || !janelaFim.After(agora) || janelaFim.After(agora.Add(janelaMaximaListaEspera)) {
+		return nil, errors.ErrInvalidWaitlistWindow
+	}
+
+	// Reaproveitar a validação de dono, pet, petshop e serviços feita na criação de agendamentos
+	agendamento, participantes, err := s.montarAgendamento(&dtos.AgendamentoCreateDTO{
+		DonoID:        ator.ID.String(),
+		PetID:         dto.PetID,
+		PetshopID:     dto.PetshopID,
+		DataAgendada:  dto.JanelaInicio,
+		Observacoes:   dto.Observacoes,
+		TotalPrevisto: dto.TotalPrevisto,
+		Itens:         dto.Itens,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entrada := &entities.EntradaListaEspera{
+		DonoID:        agendamento.DonoID,
+		PetID:         agendamento.PetID,
+		PetshopID:     agendamento.PetshopID,
+		JanelaInicio:  janelaInicio,
+		JanelaFim:     janelaFim,
+		Status:        entities.StatusEsperaAguardando,
+		Observacoes:   agendamento.Observacoes,
+		TotalPrevisto: agendamento.TotalPrevisto,
+		Itens:         []entities.ItemListaEspera{},
+	}
+	for _, item := range agendamento.Itens {
+		entrada.Itens = append(entrada.Itens, entities.ItemListaEspera{
+			ServicoID:      item.ServicoID,
+			NomeServico:    item.NomeServico,
+			PrecoPrevisto:  item.PrecoPrevisto,
+			DuracaoMinutos: item.DuracaoMinutos,
+		})
+	}
+
+	if err := s.listaEsperaRepository.Create(entrada); err != nil {
+		return nil, errors.ErrFailedToCreateWaitlist
+	}
+
+	return s.entradaToResponseDTO(entrada, participantes.petshop), nil
+}
+
+// GetListaEsperaByID busca uma entrada da lista de espera pelo ID
+func (s *AgendamentoService) GetListaEsperaByID(id ksuid.KSUID) (*dtos.ListaEsperaResponseDTO, error) {
+	entrada, err := s.listaEsperaRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToFetchWaitlist
+	}
+
+	petshop, err := s.petshopRepository.GetByID(entrada.PetshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	return s.entradaToResponseDTO(entrada, petshop), nil
+}
+
+// GetListaEsperaByDonoID lista as entradas da lista de espera de um dono, das mais recentes para as mais antigas
+func (s *AgendamentoService) GetListaEsperaByDonoID(donoID ksuid.KSUID) ([]dtos.ListaEsperaResponseDTO, error) {
+	entradas, err := s.listaEsperaRepository.GetByDonoID(donoID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchWaitlist
+	}
+
+	entradasDTO := []dtos.ListaEsperaResponseDTO{}
+	for i := range entradas {
+		petshop, err := s.petshopRepository.GetByID(entradas[i].PetshopID)
+		if err != nil {
+			continue // Pular esta entrada se não for possível buscar o petshop
+		}
+		entradasDTO = append(entradasDTO, *s.entradaToResponseDTO(&entradas[i], petshop))
+	}
+	return entradasDTO, nil
+}
+
+// GetListaEsperaByPetshopID lista a lista de espera de um petshop por ordem de chegada
+func (s *AgendamentoService) GetListaEsperaByPetshopID(petshopID ksuid.KSUID) ([]dtos.ListaEsperaResponseDTO, error) {
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	entradas, err := s.listaEsperaRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchWaitlist
+	}
+
+	entradasDTO := []dtos.ListaEsperaResponseDTO{}
+	for i := range entradas {
+		entradasDTO = append(entradasDTO, *s.entradaToResponseDTO(&entradas[i], petshop))
+	}
+	return entradasDTO, nil
+}
+
+// AceitarOferta converte o horário ofertado à entrada em um agendamento, enquanto a reserva não expira
+func (s *AgendamentoService) AceitarOferta(id ksuid.KSUID, ator entities.Ator) (*dtos.AgendamentoResponseDTO, error) {
+	entrada, err := s.buscarEntradaDoDono(id, ator)
+	if err != nil {
+		return nil, err
+	}
+
+	if entrada.Status != entities.StatusEsperaOfertada {
+		return nil, errors.ErrWaitlistNotOffered
+	}
+	if !time.Now().Before(*entrada.OfertaExpiraEm) {
+		return nil, errors.ErrWaitlistOfferExpired
+	}
+
+	participantes, err := s.buscarParticipantes(entrada.DonoID, entrada.PetID, entrada.PetshopID)
+	if err != nil {
+		return nil, err
+	}
+
+	agendamento := &entities.Agendamento{
+		DonoID:        entrada.DonoID,
+		PetID:         entrada.PetID,
+		PetshopID:     entrada.PetshopID,
+		DataAgendada:  *entrada.OfertaInicio,
+		Status:        entities.StatusPendente,
+		Observacoes:   entrada.Observacoes,
+		TotalPrevisto: entrada.TotalPrevisto,
+		Itens:         []entities.ItemAgendamento{},
+	}
+	for _, item := range entrada.Itens {
+		agendamento.Itens = append(agendamento.Itens, entities.ItemAgendamento{
+			ServicoID:      item.ServicoID,
+			PetID:          entrada.PetID,
+			NomePet:        participantes.pet.Nome,
+			NomeServico:    item.NomeServico,
+			PrecoPrevisto:  item.PrecoPrevisto,
+			DuracaoMinutos: item.DuracaoMinutos,
+		})
+	}
+
+	agendamento.ExigeConfirmacaoPresenca, err = s.exigeConfirmacaoPresenca(participantes.petshop, entrada.DonoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marcar a oferta como aceita antes de agendar impede que ela expire e seja repassada no meio do caminho
+	if err := s.listaEsperaRepository.AtualizarStatus(id, entities.StatusEsperaOfertada, entities.StatusEsperaAceita, nil); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrWaitlistNotOffered
+		}
+		return nil, errors.ErrFailedToUpdateWaitlist
+	}
+
+	// A reserva da própria entrada não conta contra a capacidade do petshop
+	if err := s.agendarComReserva(agendamento, participantes.petshop, entrada.ID); err != nil {
+		// Devolver a oferta; se o prazo já tiver passado, o processamento periódico a repassa
+		_ = s.listaEsperaRepository.AtualizarStatus(id, entities.StatusEsperaAceita, entities.StatusEsperaOfertada, nil)
+		return nil, err
+	}
+
+	if err := s.listaEsperaRepository.AtualizarStatus(id, entities.StatusEsperaAceita, entities.StatusEsperaAceita, &agendamento.ID); err != nil {
+		return nil, errors.ErrFailedToUpdateWaitlist
+	}
+
+	return s.entityToResponseDTO(agendamento, participantes.pet.Nome, participantes.dono.Nome, participantes.petshop.Nome), nil
+}
+
+// RecusarOferta devolve o horário ofertado, que passa para a próxima entrada compatível
+func (s *AgendamentoService) RecusarOferta(id ksuid.KSUID, ator entities.Ator) (*dtos.ListaEsperaResponseDTO, error) {
+	entrada, err := s.buscarEntradaDoDono(id, ator)
+	if err != nil {
+		return nil, err
+	}
+
+	if entrada.Status != entities.StatusEsperaOfertada {
+		return nil, errors.ErrWaitlistNotOffered
+	}
+
+	if err := s.listaEsperaRepository.AtualizarStatus(id, entities.StatusEsperaOfertada, entities.StatusEsperaRecusada, nil); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrWaitlistNotOffered
+		}
+		return nil, errors.ErrFailedToUpdateWaitlist
+	}
+
+	s.repassarOferta(entrada)
+	return s.GetListaEsperaByID(id)
+}
+
+// CancelarEntrada retira o dono da lista de espera; um horário que estava ofertado passa para a próxima entrada
+func (s *AgendamentoService) CancelarEntrada(id ksuid.KSUID, ator entities.Ator) (*dtos.ListaEsperaResponseDTO, error) {
+	entrada, err := s.buscarEntradaDoDono(id, ator)
+	if err != nil {
+		return nil, err
+	}
+
+	if entrada.Status != entities.StatusEsperaAguardando && entrada.Status != entities.StatusEsperaOfertada {
+		return nil, errors.ErrWaitlistEntryClosed
+	}
+
+	if err := s.listaEsperaRepository.AtualizarStatus(id, entrada.Status, entities.StatusEsperaCancelada, nil); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrWaitlistEntryClosed
+		}
+		return nil, errors.ErrFailedToUpdateWaitlist
+	}
+
+	if entrada.Status == entities.StatusEsperaOfertada {
+		s.repassarOferta(entrada)
+	}
+	return s.GetListaEsperaByID(id)
+}
+
+// ProcessarListaEspera encerra as ofertas cujo prazo de reserva terminou, repassando os horários
+// para as próximas entradas, e as entradas cuja janela de datas já passou
+func (s *AgendamentoService) ProcessarListaEspera() error {
+	agora := time.Now()
+
+	expiradas, err := s.listaEsperaRepository.GetOfertasExpiradas(agora)
+	if err != nil {
+		return errors.ErrFailedToFetchWaitlist
+	}
+	for i := range expiradas {
+		err := s.listaEsperaRepository.AtualizarStatus(expiradas[i].ID, entities.StatusEsperaOfertada, entities.StatusEsperaExpirada, nil)
+		if err != nil {
+			continue // A entrada foi aceita, recusada ou cancelada enquanto isso
+		}
+		s.repassarOferta(&expiradas[i])
+	}
+
+	if err := s.listaEsperaRepository.ExpirarJanelasVencidas(agora); err != nil {
+		return errors.ErrFailedToUpdateWaitlist
+	}
+	return nil
+}
+
+// IniciarProcessamentoListaEspera executa ProcessarListaEspera periodicamente em segundo plano
+func (s *AgendamentoService) IniciarProcessamentoListaEspera(intervalo time.Duration) {
+	go func() {
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := s.ProcessarListaEspera(); err != nil {
+				log.Printf("Erro ao processar lista de espera: %v", err)
+			}
+		}
+	}()
+}
+
+// ofertarHorarioLiberado oferta o horário que começa em inicio à primeira entrada aguardando, por ordem
+// de chegada, cujo atendimento caiba na janela de datas, no horário de funcionamento, na capacidade
+// e nos recursos físicos do petshop e na disponibilidade dos funcionários.
+// A oferta é feita no melhor esforço: falhas não afetam a operação que liberou o horário
+func (s *AgendamentoService) ofertarHorarioLiberado(petshopID ksuid.KSUID, inicio time.Time) {
+	agora := time.Now()
+	if !inicio.After(agora) {
+		return
+	}
+
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		return
+	}
+
+	entradas, err := s.listaEsperaRepository.GetAguardandoParaHorario(petshopID, inicio)
+	if err != nil {
+		return
+	}
+
+	funcionarios, err := s.funcionarioRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return
+	}
+
+	recursos, err := s.recursoRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return
+	}
+
+	for i := range entradas {
+		entrada := &entradas[i]
+		fim := inicio.Add(entrada.Duracao())
+		if fim.After(entrada.JanelaFim) {
+			continue
+		}
+		if s.validarHorarioFuncionamento(petshop, inicio, fim) != nil || s.validarFechamentos(petshop, inicio, fim) != nil {
+			continue
+		}
+
+		ativos, err := s.agendamentoRepository.GetAtivosNoPeriodo(petshopID, inicio, fim)
+		if err != nil {
+			return
+		}
+		if verificarCapacidade(&entities.Agendamento{DataAgendada: inicio, DataFim: fim}, petshop.Capacidade)(ativos, nil) != nil {
+			continue
+		}
+
+		// Os recursos físicos usados pelos serviços precisam ter unidades livres
+		entrada.OfertaInicio = &inicio
+		entrada.OfertaFim = &fim
+		reserva := entrada.Reserva()
+		if excedeRecursos(&reserva, ativos, recursos) {
+			continue
+		}
+
+		// Com funcionários cadastrados, algum habilitado para os serviços precisa estar livre
+		var servicoIDs []ksuid.KSUID
+		for _, item := range entrada.Itens {
+			servicoIDs = append(servicoIDs, item.ServicoID)
+		}
+		totalAtivos, habilitados := funcionariosHabilitados(funcionarios, servicoIDs)
+		if totalAtivos > 0 && escolherFuncionario(habilitados, funcionariosOcupados(inicio, fim, ativos)) == nil {
+			continue
+		}
+
+		// A reserva dura o prazo padrão, mas nunca além do início do atendimento
+		expiraEm := agora.Add(entities.PrazoReservaListaEspera)
+		if expiraEm.After(inicio) {
+			expiraEm = inicio
+		}
+		entrada.OfertaExpiraEm = &expiraEm
+		if s.listaEsperaRepository.Ofertar(entrada) == nil {
+			return
+		}
+	}
+}
+
+// repassarOferta oferta à próxima entrada o horário que estava reservado para a entrada informada
+func (s *AgendamentoService) repassarOferta(entrada *entities.EntradaListaEspera) {
+	if entrada.OfertaInicio != nil {
+		s.ofertarHorarioLiberado(entrada.PetshopID, *entrada.OfertaInicio)
+	}
+}
+
+// buscarEntradaDoDono busca a entrada garantindo que apenas o próprio dono a gerencie
+func (s *AgendamentoService) buscarEntradaDoDono(id ksuid.KSUID, ator entities.Ator) (*entities.EntradaListaEspera, error) {
+	entrada, err := s.listaEsperaRepository.GetByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToFetchWaitlist
+	}
+
+	if ator.Tipo != entities.AtorDono || ator.ID != entrada.DonoID {
+		return nil, errors.ErrOnlyDonoManagesWaitlist
+	}
+	return entrada, nil
+}
+
+// Helper para converter a entrada da lista de espera para DTO de resposta
+func (s *AgendamentoService) entradaToResponseDTO(entrada *entities.EntradaListaEspera, petshop *entities.Petshop) *dtos.ListaEsperaResponseDTO {
+	itensDTO := []dtos.ItemAgendamentoResponseDTO{}
+	for _, item := range entrada.Itens {
+		itensDTO = append(itensDTO, dtos.ItemAgendamentoResponseDTO{
+			ID:             item.ID.String(),
+			ServicoID:      item.ServicoID.String(),
+			NomeServico:    item.NomeServico,
+			PrecoPrevisto:  item.PrecoPrevisto,
+			DuracaoMinutos: item.DuracaoMinutos,
+		})
+	}
+
+	loc := petshop.Localizacao()
+	response := &dtos.ListaEsperaResponseDTO{
+		ID:            entrada.ID.String(),
+		DonoID:        entrada.DonoID.String(),
+		PetID:         entrada.PetID.String(),
+		PetshopID:     entrada.PetshopID.String(),
+		JanelaInicio:  entrada.JanelaInicio.In(loc).Format(time.RFC3339),
+		JanelaFim:     entrada.JanelaFim.In(loc).Format(time.RFC3339),
+		Status:        string(entrada.Status),
+		Observacoes:   entrada.Observacoes,
+		TotalPrevisto: entrada.TotalPrevisto,
+		Itens:         itensDTO,
+		CreatedAt:     entrada.CreatedAt.Format(time.RFC3339),
+	}
+
+	if entrada.Status == entities.StatusEsperaOfertada && entrada.OfertaInicio != nil {
+		response.Oferta = &dtos.OfertaListaEsperaDTO{
+			Inicio:   entrada.OfertaInicio.In(loc).Format(time.RFC3339),
+			Fim:      entrada.OfertaFim.In(loc).Format(time.RFC3339),
+			ExpiraEm: entrada.OfertaExpiraEm.Format(time.RFC3339),
+		}
+	}
+	if entrada.AgendamentoID != nil {
+		response.AgendamentoID = entrada.AgendamentoID.String()
+	}
+	return response
+}
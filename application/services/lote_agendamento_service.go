@@ -0,0 +1,347 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// Operações aceitas pelas operações em lote
+const (
+	OperacaoLoteConfirmar = "confirmar"
+	OperacaoLoteCancelar  = "cancelar"
+	OperacaoLoteDeslocar  = "deslocar"
+)
+
+// Limites das operações em lote
+const (
+	periodoMaximoLote      = 31 * 24 * time.Hour
+	deslocamentoMaximoLote = 7 * 24 * 60 // Em minutos
+)
+
+// itemLote acompanha um agendamento da operação em lote até o relatório
+type itemLote struct {
+	resultado    dtos.ResultadoLoteDTO
+	agendamento  *entities.Agendamento
+	alteracao    *entities.AlteracaoAgendamento
+	dataAnterior time.Time
+}
+
+// AplicarLote confirma, cancela ou desloca de uma só vez os agendamentos do petshop escolhidos no lote.
+// As alterações válidas são gravadas em uma única transação; sem parcial, nada é gravado se algum
+// agendamento falhar, e com parcial cada agendamento que falha na gravação é desfeito isoladamente. O relatório traz o resultado de cada agendamento
+func (s *AgendamentoService) AplicarLote(petshopID ksuid.KSUID, dto *dtos.AgendamentoLoteDTO, ator entities.Ator) (*dtos.AgendamentoLoteResponseDTO, error) {
+	if err := validarLote(dto); err != nil {
+		return nil, err
+	}
+
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	itens, err := s.selecionarLote(petshop, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validar e preparar a alteração de cada agendamento
+	agora := time.Now()
+	var aplicar []*itemLote
+	falhas := 0
+	for _, item := range itens {
+		if item.agendamento != nil {
+			item.alteracao, err = s.prepararAlteracaoLote(item.agendamento, petshop, dto, ator, agora)
+			if err != nil {
+				item.resultado.Erro = err.Error()
+			} else {
+				aplicar = append(aplicar, item)
+			}
+		}
+		if item.resultado.Erro != "" {
+			falhas++
+		}
+	}
+
+	if len(aplicar) == 0 || (falhas > 0 && !dto.Parcial) {
+		marcarNaoAplicados(aplicar)
+		return relatorioLote(dto.Operacao, itens, false), nil
+	}
+
+	// Ao deslocar, os agendamentos que liberam o horário dos seguintes são gravados primeiro:
+	// os mais tardios ao adiar e os mais cedo ao antecipar
+	sort.SliceStable(aplicar, func(a, b int) bool {
+		if dto.DeslocamentoMinutos > 0 {
+			return aplicar[a].dataAnterior.After(aplicar[b].dataAnterior)
+		}
+		return aplicar[a].dataAnterior.Before(aplicar[b].dataAnterior)
+	})
+
+	alteracoes := make([]entities.AlteracaoAgendamento, len(aplicar))
+	for i, item := range aplicar {
+		alteracoes[i] = *item.alteracao
+	}
+	falhasGravacao, err := s.agendamentoRepository.AplicarLote(alteracoes, dto.Parcial)
+	if err != nil {
+		marcarNaoAplicados(aplicar)
+	}
+
+	var aplicados []*itemLote
+	for i, item := range aplicar {
+		if falhasGravacao[i] != nil {
+			item.resultado.Erro = erroGravacaoLote(falhasGravacao[i]).Error()
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		item.resultado.Sucesso = true
+		item.resultado.Status = string(item.agendamento.Status)
+		item.resultado.DataAgendada = item.agendamento.DataAgendada.Format(time.RFC3339)
+		aplicados = append(aplicados, item)
+	}
+	if len(aplicados) == 0 {
+		return relatorioLote(dto.Operacao, itens, false), nil
+	}
+
+	// Os horários liberados por cancelamentos e deslocamentos são ofertados à lista de espera
+	if dto.Operacao != OperacaoLoteConfirmar {
+		for _, item := range aplicados {
+			s.ofertarHorarioLiberado(petshop.ID, item.dataAnterior)
+		}
+	}
+
+	return relatorioLote(dto.Operacao, itens, true), nil
+}
+
+// validarLote verifica a forma de seleção e os dados exigidos por cada operação
+func validarLote(dto *dtos.AgendamentoLoteDTO) error {
+	porIDs := len(dto.IDs) > 0
+	porPeriodo := dto.Inicio != "" || dto.Fim != ""
+	if porIDs == porPeriodo {
+		return errors.ErrInvalidBulkOperation
+	}
+
+	switch dto.Operacao {
+	case OperacaoLoteCancelar:
+		if strings.TrimSpace(dto.Motivo) == "" {
+			return errors.ErrInvalidBulkOperation
+		}
+	case OperacaoLoteDeslocar:
+		if dto.DeslocamentoMinutos == 0 || dto.DeslocamentoMinutos > deslocamentoMaximoLote || dto.DeslocamentoMinutos < -deslocamentoMaximoLote {
+			return errors.ErrInvalidBulkOperation
+		}
+	}
+	return nil
+}
+
+// selecionarLote busca os agendamentos do lote. Pelos ids, cada agendamento inexistente ou de outro
+// petshop entra no relatório como falha. Pelo período, apenas os agendamentos aos quais a operação se
+// aplica são selecionados (pendentes ao confirmar; pendentes e confirmados nas demais operações)
+func (s *AgendamentoService) selecionarLote(petshop *entities.Petshop, dto *dtos.AgendamentoLoteDTO) ([]*itemLote, error) {
+	var itens []*itemLote
+
+	if len(dto.IDs) > 0 {
+		vistos := make(map[string]bool, len(dto.IDs))
+		for _, idStr := range dto.IDs {
+			if vistos[idStr] {
+				continue
+			}
+			vistos[idStr] = true
+
+			item := &itemLote{resultado: dtos.ResultadoLoteDTO{AgendamentoID: idStr}}
+			itens = append(itens, item)
+
+			id, err := ksuid.Parse(idStr)
+			if err != nil {
+				item.resultado.Erro = errors.ErrInvalidID.Error()
+				continue
+			}
+			agendamento, err := s.agendamentoRepository.GetByID(id)
+			if err != nil {
+				if err == errors.ErrNotFound {
+					item.resultado.Erro = errors.ErrNotFound.Error()
+				} else {
+					item.resultado.Erro = errors.ErrFailedToCheckAgendamento.Error()
+				}
+				continue
+			}
+			if agendamento.PetshopID != petshop.ID {
+				item.resultado.Erro = errors.ErrAgendamentoNotFromPetshop.Error()
+				continue
+			}
+			item.agendamento = agendamento
+			item.dataAnterior = agendamento.DataAgendada
+		}
+		return itens, nil
+	}
+
+	inicio, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.Inicio)
+	if err != nil {
+		return nil, errors.ErrInvalidBulkOperation
+	}
+	fim, err := time.Parse("2006-01-02T15:04:05Z07:00", dto.Fim)
+	if err != nil || !fim.After(inicio) || fim.Sub(inicio) > periodoMaximoLote {
+		return nil, errors.ErrInvalidBulkOperation
+	}
+
+	var funcionarioID *ksuid.KSUID
+	if dto.FuncionarioID != "" {
+		id, err := ksuid.Parse(dto.FuncionarioID)
+		if err != nil {
+			return nil, errors.ErrInvalidID
+		}
+		funcionarioID = &id
+	}
+
+	agendamentos, err := s.agendamentoRepository.GetAgendaNoPeriodo(petshop.ID, inicio, fim)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchAgendamentos
+	}
+
+	for i := range agendamentos {
+		agendamento := &agendamentos[i]
+		if funcionarioID != nil && (agendamento.FuncionarioID == nil || *agendamento.FuncionarioID != *funcionarioID) {
+			continue
+		}
+		if dto.Operacao == OperacaoLoteConfirmar && agendamento.Status != entities.StatusPendente {
+			continue
+		}
+		if !agendamento.Status.OcupaAgenda() {
+			continue
+		}
+		itens = append(itens, &itemLote{
+			resultado:    dtos.ResultadoLoteDTO{AgendamentoID: agendamento.ID.String()},
+			agendamento:  agendamento,
+			dataAnterior: agendamento.DataAgendada,
+		})
+	}
+	return itens, nil
+}
+
+// prepararAlteracaoLote valida a operação para o agendamento e aplica a alteração em memória, com o
+// registro de histórico. Deslocamentos recebem a verificação de disponibilidade do novo horário
+func (s *AgendamentoService) prepararAlteracaoLote(agendamento *entities.Agendamento, petshop *entities.Petshop, dto *dtos.AgendamentoLoteDTO, ator entities.Ator, agora time.Time) (*entities.AlteracaoAgendamento, error) {
+	historico := &entities.HistoricoAgendamento{
+		AgendamentoID:  agendamento.ID,
+		StatusAnterior: agendamento.Status,
+		AtorID:         ator.ID,
+		AtorTipo:       ator.Tipo,
+		Motivo:         dto.Motivo,
+	}
+	alteracao := &entities.AlteracaoAgendamento{Agendamento: agendamento, Historico: historico}
+
+	switch dto.Operacao {
+	case OperacaoLoteConfirmar, OperacaoLoteCancelar:
+		novoStatus := entities.StatusConfirmado
+		if dto.Operacao == OperacaoLoteCancelar {
+			novoStatus = entities.StatusCancelado
+		}
+		if err := validarTransicaoStatus(agendamento.Status, novoStatus, ator); err != nil {
+			return nil, err
+		}
+
+		// Donos com muitas faltas precisam confirmar presença antes da confirmação do petshop
+		if novoStatus == entities.StatusConfirmado && agendamento.AguardandoConfirmacaoPresenca() {
+			return nil, errors.ErrAwaitingPresenceConfirm
+		}
+
+		agendamento.Status = novoStatus
+		if novoStatus == entities.StatusCancelado {
+			registrarCancelamento(agendamento, petshop, ator, agora)
+		}
+
+	case OperacaoLoteDeslocar:
+		// Agendamentos encerrados ou com o atendimento já iniciado não podem ser remarcados
+		if agendamento.Status.Encerrado() {
+			return nil, errors.ErrAgendamentoUpdateForbidden
+		}
+		if agendamento.AtendimentoIniciado() {
+			return nil, errors.ErrRescheduleAfterStart
+		}
+
+		dataAnterior := agendamento.DataAgendada
+		novaData := dataAnterior.Add(time.Duration(dto.DeslocamentoMinutos) * time.Minute)
+		if novaData.Before(agora) {
+			return nil, errors.ErrPastDate
+		}
+
+		agendamento.DataAgendada = novaData
+		agendamento.CalcularDataFim()
+
+		if err := s.validarHorarioFuncionamento(petshop, agendamento.DataAgendada, agendamento.DataFim); err != nil {
+			return nil, err
+		}
+		// Verificado também aqui para que o agendamento fechado seja relatado sem impedir o restante do lote
+		if err := s.validarFechamentos(petshop, agendamento.DataAgendada, agendamento.DataFim); err != nil {
+			return nil, err
+		}
+
+		// Uma nova data resolve a necessidade de remarcação causada por fechamento
+		agendamento.Remarcacoes++
+		agendamento.RequerRemarcacao = false
+		agendamento.MotivoRemarcacao = ""
+		historico.DataAnterior = &dataAnterior
+		historico.DataNova = &novaData
+
+		// Os fechamentos são verificados de novo com o petshop bloqueado, e os recursos físicos e o funcionário
+		// atribuído precisam estar livres na nova data
+		verificar := verificarFechamentos(agendamento, petshop, verificarCapacidade(agendamento, petshop.Capacidade))
+		verificar, err := s.verificarRecursos(agendamento, petshop, verificar)
+		if err != nil {
+			return nil, err
+		}
+		alteracao.Verificar, err = s.verificarFuncionario(agendamento, petshop, verificar)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	historico.StatusNovo = agendamento.Status
+	return alteracao, nil
+}
+
+// erroGravacaoLote converte o erro da gravação de um agendamento do lote no erro relatado
+func erroGravacaoLote(err error) error {
+	switch err {
+	case errors.ErrHorarioIndisponivel, errors.ErrRecursoIndisponivel, errors.ErrFuncionarioIndisponivel,
+		errors.ErrNoStaffAvailable, errors.ErrAgendamentoVersionConflict, errors.ErrPetshopClosed:
+		return err
+	default:
+		return errors.ErrFailedToApplyBulkOperation
+	}
+}
+
+// marcarNaoAplicados registra a falha dos agendamentos válidos quando o lote não é gravado
+func marcarNaoAplicados(itens []*itemLote) {
+	for _, item := range itens {
+		item.resultado.Erro = errors.ErrBulkOperationNotApplied.Error()
+	}
+}
+
+// relatorioLote monta o relatório da operação em lote na ordem de seleção dos agendamentos
+func relatorioLote(operacao string, itens []*itemLote, aplicado bool) *dtos.AgendamentoLoteResponseDTO {
+	relatorio := &dtos.AgendamentoLoteResponseDTO{
+		Operacao:   operacao,
+		Aplicado:   aplicado,
+		Total:      len(itens),
+		Resultados: []dtos.ResultadoLoteDTO{},
+	}
+	for _, item := range itens {
+		if item.resultado.Sucesso {
+			relatorio.Sucessos++
+		} else {
+			relatorio.Falhas++
+		}
+		relatorio.Resultados = append(relatorio.Resultados, item.resultado)
+	}
+	return relatorio
+}
@@ -49,6 +49,8 @@ func (s *PetshopService) Create(dto *dtos.PetshopCreateDTO) (*dtos.PetshopDetail
 		Descricao:   dto.Descricao,
 		Ativo:       true, // Por padrão, o petshop é criado como ativo
 		Nota:        0,    // Inicialmente sem avaliações
+		Capacidade:  1,    // Por padrão, um atendimento por vez
+		FusoHorario: entities.FusoHorarioPadrao,
 	}
 
 	// Gerar hash da senha
@@ -98,6 +100,26 @@ func (s *PetshopService) Update(id ksuid.KSUID, dto *dtos.PetshopUpdateDTO) (*dt
 	petshop.Email = dto.Email
 	petshop.Telefone = dto.Telefone
 	petshop.Descricao = dto.Descricao
+	if dto.Capacidade > 0 {
+		petshop.Capacidade = dto.Capacidade
+	}
+	if dto.FusoHorario != "" {
+		petshop.FusoHorario = dto.FusoHorario
+	}
+	if politica := dto.PoliticaCancelamento; politica != nil {
+		if politica.TipoTaxa == string(entities.TaxaCancelamentoPercentual) && politica.ValorTaxa > 100 {
+			return nil, errors.ErrInvalidCancelPolicy
+		}
+		petshop.PrazoCancelamentoHoras = politica.PrazoHoras
+		petshop.TipoTaxaCancelamento = entities.TipoTaxaCancelamento(politica.TipoTaxa)
+		petshop.ValorTaxaCancelamento = politica.ValorTaxa
+	}
+	if dto.LimiteFaltasConfirmacao != nil {
+		petshop.LimiteFaltasConfirmacao = *dto.LimiteFaltasConfirmacao
+	}
+	if dto.ExigeReconfirmacaoRemarcacao != nil {
+		petshop.ExigeReconfirmacaoRemarcacao = *dto.ExigeReconfirmacaoRemarcacao
+	}
 
 	// Salvar no repositório
 	if err := s.petshopRepository.Update(petshop); err != nil {
@@ -190,7 +212,7 @@ func (s *PetshopService) List(page, limit int) ([]dtos.PetshopListItemDTO, error
 
 // Helper para converter entidade Petshop para DTO de detalhe
 func (s *PetshopService) entityToDetailDTO(petshop *entities.Petshop) *dtos.PetshopDetailDTO {
-	return &dtos.PetshopDetailDTO{
+	detalhe := &dtos.PetshopDetailDTO{
 		ID:          petshop.ID,
 		Nome:        petshop.Nome,
 		Email:       petshop.Email,
@@ -205,7 +227,17 @@ func (s *PetshopService) entityToDetailDTO(petshop *entities.Petshop) *dtos.Pets
 		Descricao:   petshop.Descricao,
 		Nota:        petshop.Nota,
 		Ativo:       petshop.Ativo,
+		Capacidade:  petshop.Capacidade,
+		FusoHorario: petshop.Localizacao().String(),
 		CreatedAt:   petshop.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   petshop.UpdatedAt.Format(time.RFC3339),
-	}
+		PoliticaCancelamento: dtos.PoliticaCancelamentoDTO{
+			PrazoHoras: petshop.PrazoCancelamentoHoras,
+			TipoTaxa:   string(petshop.TipoTaxaCancelamento),
+			ValorTaxa:  petshop.ValorTaxaCancelamento,
+		},
+		LimiteFaltasConfirmacao: petshop.LimiteFaltasConfirmacao,
+	}
+	detalhe.ExigeReconfirmacaoRemarcacao = petshop.ExigeReconfirmacaoRemarcacao
+	return detalhe
 }
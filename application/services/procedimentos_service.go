@@ -123,7 +123,7 @@ func (s *ProcedimentoService) Create(dto *dtos.ProcedimentoCreateDTO) (*dtos.Pro
 
 	// Salvar no repositório
 	if err := s.procedimentoRepository.Create(procedimento); err != nil {
-		return nil, errors.ErrFailedToCreateService
+		return nil, errors.ErrFailedToCreateProcedure
 	}
 
 	// Preparar DTO de resposta
@@ -155,6 +155,41 @@ func (s *ProcedimentoService) GetByPetID(petID ksuid.KSUID) ([]dtos.Procedimento
 	return procedimentoDTOs, nil
 }
 
+// GetByPetshopID lista todos os procedimentos realizados por um determinado petshop
+func (s *ProcedimentoService) GetByPetshopID(petshopID ksuid.KSUID) ([]dtos.ProcedimentoResponseDTO, error) {
+	// Verificar se o petshop existe
+	if _, err := s.petshopRepository.GetByID(petshopID); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	// Buscar procedimentos
+	procedimentos, err := s.procedimentoRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToCheckProcedure
+	}
+
+	// Converter para DTOs, buscando o nome de cada pet uma única vez
+	nomesPets := make(map[ksuid.KSUID]string)
+	procedimentoDTOs := []dtos.ProcedimentoResponseDTO{}
+	for _, procedimento := range procedimentos {
+		nomePet, ok := nomesPets[procedimento.PetID]
+		if !ok {
+			pet, err := s.petRepository.GetByID(procedimento.PetID)
+			if err != nil {
+				return nil, errors.ErrFailedToFetchPetInfo
+			}
+			nomePet = pet.Nome
+			nomesPets[procedimento.PetID] = nomePet
+		}
+		procedimentoDTOs = append(procedimentoDTOs, *s.entityToResponseDTO(&procedimento, nomePet))
+	}
+
+	return procedimentoDTOs, nil
+}
+
 // Helper para converter entidade Procedimento para DTO
 func (s *ProcedimentoService) entityToResponseDTO(procedimento *entities.Procedimento, nomePet string) *dtos.ProcedimentoResponseDTO {
 	// Converter itens
@@ -168,11 +203,17 @@ func (s *ProcedimentoService) entityToResponseDTO(procedimento *entities.Procedi
 		})
 	}
 
+	var agendamentoID string
+	if procedimento.AgendamentoID != nil {
+		agendamentoID = procedimento.AgendamentoID.String()
+	}
+
 	return &dtos.ProcedimentoResponseDTO{
 		ID:             procedimento.ID.String(),
 		PetID:          procedimento.PetID.String(),
 		NomePet:        nomePet,
 		PetshopID:      procedimento.PetshopID.String(),
+		AgendamentoID:  agendamentoID,
 		NomePetshop:    procedimento.NomePetshop,
 		DataRealizacao: procedimento.DataRealizacao.Format(time.RFC3339),
 		Observacoes:    procedimento.Observacoes,
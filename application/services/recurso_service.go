@@ -0,0 +1,196 @@
+package services
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/interfaces/repositories"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// RecursoService fornece métodos para gerenciar os recursos físicos dos petshops
+// e os recursos consumidos por cada serviço
+type RecursoService struct {
+	recursoRepository repositories.RecursoRepository
+	petshopRepository repositories.PetshopRepository
+	servicoRepository repositories.ServicoRepository
+}
+
+// NewRecursoService cria uma nova instância de RecursoService
+func NewRecursoService(
+	recursoRepo repositories.RecursoRepository,
+	petshopRepo repositories.PetshopRepository,
+	servicoRepo repositories.ServicoRepository,
+) *RecursoService {
+	return &RecursoService{
+		recursoRepository: recursoRepo,
+		petshopRepository: petshopRepo,
+		servicoRepository: servicoRepo,
+	}
+}
+
+// Create cadastra um recurso físico no petshop
+func (s *RecursoService) Create(petshopID ksuid.KSUID, dto *dtos.RecursoCreateDTO) (*dtos.RecursoResponseDTO, error) {
+	// Verificar se o petshop existe
+	if _, err := s.petshopRepository.GetByID(petshopID); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	if dto.Quantidade < 1 {
+		return nil, errors.ErrInvalidResourceQuantity
+	}
+
+	recurso := &entities.Recurso{
+		PetshopID:  petshopID,
+		Nome:       dto.Nome,
+		Quantidade: dto.Quantidade,
+	}
+
+	if err := s.recursoRepository.Create(recurso); err != nil {
+		return nil, errors.ErrFailedToCreateResource
+	}
+
+	return s.entityToResponseDTO(recurso), nil
+}
+
+// GetByPetshopID lista os recursos de um petshop e os serviços que consomem cada um
+func (s *RecursoService) GetByPetshopID(petshopID ksuid.KSUID) ([]dtos.RecursoResponseDTO, error) {
+	// Verificar se o petshop existe
+	if _, err := s.petshopRepository.GetByID(petshopID); err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrPetshopNotFound
+		}
+		return nil, errors.ErrFailedToCheckPetshop
+	}
+
+	recursos, err := s.recursoRepository.GetByPetshopID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchResources
+	}
+
+	recursoDTOs := []dtos.RecursoResponseDTO{}
+	for i := range recursos {
+		recursoDTOs = append(recursoDTOs, *s.entityToResponseDTO(&recursos[i]))
+	}
+	return recursoDTOs, nil
+}
+
+// Update atualiza o nome e a quantidade de um recurso do petshop.
+// Agendamentos já existentes não são revalidados contra a nova quantidade
+func (s *RecursoService) Update(petshopID, recursoID ksuid.KSUID, dto *dtos.RecursoUpdateDTO) (*dtos.RecursoResponseDTO, error) {
+	recurso, err := s.buscarDoPetshop(petshopID, recursoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Quantidade < 1 {
+		return nil, errors.ErrInvalidResourceQuantity
+	}
+
+	recurso.Nome = dto.Nome
+	recurso.Quantidade = dto.Quantidade
+
+	if err := s.recursoRepository.Update(recurso); err != nil {
+		return nil, errors.ErrFailedToUpdateResource
+	}
+
+	return s.entityToResponseDTO(recurso), nil
+}
+
+// Delete remove um recurso do petshop; os serviços deixam de consumi-lo
+func (s *RecursoService) Delete(petshopID, recursoID ksuid.KSUID) error {
+	if _, err := s.buscarDoPetshop(petshopID, recursoID); err != nil {
+		return err
+	}
+
+	if err := s.recursoRepository.Delete(recursoID); err != nil {
+		return errors.ErrFailedToDeleteResource
+	}
+	return nil
+}
+
+// DefinirConsumos substitui os recursos consumidos por um serviço e retorna os recursos
+// do petshop atualizados
+func (s *RecursoService) DefinirConsumos(servicoID ksuid.KSUID, dto *dtos.ServicoConsumosDTO) ([]dtos.RecursoResponseDTO, error) {
+	servico, err := s.servicoRepository.GetByID(servicoID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrServiceNotFound
+		}
+		return nil, errors.ErrFailedToCheckService
+	}
+
+	consumos := []entities.ConsumoRecurso{}
+	vistos := make(map[ksuid.KSUID]bool)
+	for _, consumoDTO := range dto.Consumos {
+		recursoID, err := ksuid.Parse(consumoDTO.RecursoID)
+		if err != nil {
+			return nil, errors.ErrInvalidID
+		}
+		if vistos[recursoID] {
+			continue
+		}
+		vistos[recursoID] = true
+
+		if _, err := s.buscarDoPetshop(servico.PetshopID, recursoID); err != nil {
+			return nil, err
+		}
+
+		if consumoDTO.DuracaoMinutos < 0 || time.Duration(consumoDTO.DuracaoMinutos)*time.Minute > servico.Duracao() {
+			return nil, errors.ErrInvalidResourceUsage
+		}
+
+		consumos = append(consumos, entities.ConsumoRecurso{
+			RecursoID:      recursoID,
+			ServicoID:      servicoID,
+			DuracaoMinutos: consumoDTO.DuracaoMinutos,
+		})
+	}
+
+	if err := s.recursoRepository.SubstituirConsumosDoServico(servicoID, consumos); err != nil {
+		return nil, errors.ErrFailedToUpdateResource
+	}
+
+	return s.GetByPetshopID(servico.PetshopID)
+}
+
+// buscarDoPetshop busca o recurso garantindo que ele pertence ao petshop
+func (s *RecursoService) buscarDoPetshop(petshopID, recursoID ksuid.KSUID) (*entities.Recurso, error) {
+	recurso, err := s.recursoRepository.GetByID(recursoID)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrRecursoNotFound
+		}
+		return nil, errors.ErrFailedToFetchResources
+	}
+
+	if recurso.PetshopID != petshopID {
+		return nil, errors.ErrRecursoNotFromPetshop
+	}
+	return recurso, nil
+}
+
+// Helper para converter entidade Recurso para DTO de resposta
+func (s *RecursoService) entityToResponseDTO(recurso *entities.Recurso) *dtos.RecursoResponseDTO {
+	consumosDTO := []dtos.ConsumoRecursoResponseDTO{}
+	for _, consumo := range recurso.Consumos {
+		consumosDTO = append(consumosDTO, dtos.ConsumoRecursoResponseDTO{
+			ServicoID:      consumo.ServicoID.String(),
+			DuracaoMinutos: consumo.DuracaoMinutos,
+		})
+	}
+
+	return &dtos.RecursoResponseDTO{
+		ID:         recurso.ID.String(),
+		PetshopID:  recurso.PetshopID.String(),
+		Nome:       recurso.Nome,
+		Quantidade: recurso.Quantidade,
+		Consumos:   consumosDTO,
+		CreatedAt:  recurso.CreatedAt.Format(time.RFC3339),
+	}
+}
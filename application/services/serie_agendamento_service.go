@@ -0,0 +1,244 @@
+package services
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// CreateSerie cria uma série de agendamentos recorrentes, materializando antecipadamente cada ocorrência.
+// Ocorrências que não puderem ser agendadas (conflito, fechamento, fora do horário) são relatadas em Falhas.
+// Se nenhuma ocorrência puder ser agendada, a série é descartada e ErrNoOccurrencesScheduled é retornado
+// junto com a resposta contendo as falhas
+func (s *AgendamentoService) CreateSerie(dto *dtos.SerieAgendamentoCreateDTO) (*dtos.SerieAgendamentoResponseDTO, error) {
+	modelo, participantes, err := s.montarAgendamento(&dto.AgendamentoCreateDTO)
+	if err != nil {
+		return nil, err
+	}
+	loc := participantes.petshop.Localizacao()
+
+	serie := &entities.SerieAgendamento{
+		DonoID:     modelo.DonoID,
+		PetID:      modelo.PetID,
+		PetshopID:  modelo.PetshopID,
+		Frequencia: entities.FrequenciaSerie(dto.Frequencia),
+		DataInicio: modelo.DataAgendada,
+		Quantidade: dto.Quantidade,
+	}
+
+	// A data limite é inclusiva e interpretada no fuso horário do petshop
+	if dto.DataLimite != "" {
+		dia, err := time.ParseInLocation("2006-01-02", dto.DataLimite, loc)
+		if err != nil {
+			return nil, errors.ErrInvalidDate
+		}
+		limite := dia.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		if limite.Before(serie.DataInicio) {
+			return nil, errors.ErrInvalidRecurrence
+		}
+		serie.DataLimite = &limite
+	}
+
+	datas := serie.Ocorrencias(loc)
+	if len(datas) == 0 {
+		return nil, errors.ErrInvalidRecurrence
+	}
+
+	if err := s.agendamentoRepository.CreateSerie(serie); err != nil {
+		return nil, errors.ErrFailedToCreateRecurrence
+	}
+
+	// Agendar cada ocorrência individualmente, registrando as que falharem
+	var agendamentos []entities.Agendamento
+	var falhas []dtos.OcorrenciaFalhaDTO
+	for _, data := range datas {
+		ocorrencia := novaOcorrencia(modelo, data, serie.ID)
+		if err := s.agendar(ocorrencia, participantes.petshop); err != nil {
+			falhas = append(falhas, dtos.OcorrenciaFalhaDTO{
+				DataAgendada: data.Format(time.RFC3339),
+				Motivo:       err.Error(),
+			})
+			continue
+		}
+		agendamentos = append(agendamentos, *ocorrencia)
+	}
+
+	response := s.serieToResponseDTO(serie, agendamentos, participantes)
+	response.Falhas = falhas
+
+	if len(agendamentos) == 0 {
+		if err := s.agendamentoRepository.DeleteSerie(serie.ID); err != nil {
+			return nil, errors.ErrFailedToCreateRecurrence
+		}
+		return response, errors.ErrNoOccurrencesScheduled
+	}
+
+	return response, nil
+}
+
+// GetSerieByID busca uma série e todas as suas ocorrências
+func (s *AgendamentoService) GetSerieByID(id ksuid.KSUID) (*dtos.SerieAgendamentoResponseDTO, error) {
+	serie, err := s.agendamentoRepository.GetSerieByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToFetchRecurrence
+	}
+
+	agendamentos, err := s.agendamentoRepository.GetBySerieID(id)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchRecurrence
+	}
+
+	participantes, err := s.buscarParticipantes(serie.DonoID, serie.PetID, serie.PetshopID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.serieToResponseDTO(serie, agendamentos, participantes), nil
+}
+
+// CancelarSerie cancela a série inteira: todas as ocorrências futuras ainda ativas são canceladas
+// de uma só vez, com o histórico registrado e a política de cancelamento aplicada a cada uma.
+// Ocorrências passadas ou já encerradas não são alteradas
+func (s *AgendamentoService) CancelarSerie(id ksuid.KSUID, dto *dtos.SerieAgendamentoCancelDTO, ator entities.Ator) (*dtos.SerieAgendamentoResponseDTO, error) {
+	serie, err := s.agendamentoRepository.GetSerieByID(id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrFailedToFetchRecurrence
+	}
+
+	if serie.Cancelada {
+		return nil, errors.ErrRecurrenceCanceled
+	}
+
+	agendamentos, err := s.agendamentoRepository.GetBySerieID(id)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchRecurrence
+	}
+
+	petshop, err := s.petshopRepository.GetByID(serie.PetshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	agora := time.Now()
+	var cancelados []entities.Agendamento
+	var historicos []entities.HistoricoAgendamento
+	for _, agendamento := range agendamentos {
+		if agendamento.DataAgendada.Before(agora) || !agendamento.Status.PodeTransicionarPara(entities.StatusCancelado, ator.Tipo) {
+			continue
+		}
+		statusAnterior := agendamento.Status
+		agendamento.Status = entities.StatusCancelado
+		registrarCancelamento(&agendamento, petshop, ator, agora)
+
+		cancelados = append(cancelados, agendamento)
+		historicos = append(historicos, entities.HistoricoAgendamento{
+			AgendamentoID:  agendamento.ID,
+			StatusAnterior: statusAnterior,
+			StatusNovo:     entities.StatusCancelado,
+			AtorID:         ator.ID,
+			AtorTipo:       ator.Tipo,
+			Motivo:         dto.Motivo,
+		})
+	}
+
+	// Uma ocorrência alterada desde a leitura teria o status anterior do histórico errado: nada é cancelado
+	if err := s.agendamentoRepository.CancelarSerie(id, cancelados, historicos); err != nil {
+		if err == errors.ErrAgendamentoVersionConflict {
+			return nil, err
+		}
+		return nil, errors.ErrFailedToUpdateStatus
+	}
+
+	// Os horários liberados são ofertados à lista de espera
+	for _, cancelado := range cancelados {
+		s.ofertarHorarioLiberado(cancelado.PetshopID, cancelado.DataAgendada)
+	}
+
+	return s.GetSerieByID(id)
+}
+
+// novaOcorrencia copia o agendamento modelo para uma nova data, vinculando-o à série. Cada ocorrência
+// herda a exigência de confirmação de presença calculada para o dono ao montar o modelo
+func novaOcorrencia(modelo *entities.Agendamento, data time.Time, serieID ksuid.KSUID) *entities.Agendamento {
+	ocorrencia := &entities.Agendamento{
+		DonoID:                   modelo.DonoID,
+		PetID:                    modelo.PetID,
+		PetshopID:                modelo.PetshopID,
+		DataAgendada:             data,
+		Status:                   entities.StatusPendente,
+		Observacoes:              modelo.Observacoes,
+		TotalPrevisto:            modelo.TotalPrevisto,
+		SerieID:                  &serieID,
+		FuncionarioID:            modelo.FuncionarioID,
+		ExigeConfirmacaoPresenca: modelo.ExigeConfirmacaoPresenca,
+		Origem:                   modelo.Origem,
+		Itens:                    []entities.ItemAgendamento{},
+	}
+	for _, item := range modelo.Itens {
+		ocorrencia.Itens = append(ocorrencia.Itens, entities.ItemAgendamento{
+			ServicoID:      item.ServicoID,
+			PetID:          item.PetID,
+			NomePet:        item.NomePet,
+			NomeServico:    item.NomeServico,
+			PrecoPrevisto:  item.PrecoPrevisto,
+			DuracaoMinutos: item.DuracaoMinutos,
+		})
+	}
+	return ocorrencia
+}
+
+// buscarParticipantes carrega dono, pet e petshop para montar respostas
+func (s *AgendamentoService) buscarParticipantes(donoID, petID, petshopID ksuid.KSUID) (*participantesAgendamento, error) {
+	pet, err := s.petRepository.GetByID(petID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetInfo
+	}
+
+	dono, err := s.donoRepository.GetByID(donoID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchDonoInfo
+	}
+
+	petshop, err := s.petshopRepository.GetByID(petshopID)
+	if err != nil {
+		return nil, errors.ErrFailedToFetchPetshopInfo
+	}
+
+	return &participantesAgendamento{dono: dono, pet: pet, petshop: petshop}, nil
+}
+
+// Helper para converter a série e suas ocorrências para DTO de resposta
+func (s *AgendamentoService) serieToResponseDTO(serie *entities.SerieAgendamento, agendamentos []entities.Agendamento, participantes *participantesAgendamento) *dtos.SerieAgendamentoResponseDTO {
+	agendamentosDTO := []dtos.AgendamentoResponseDTO{}
+	for i := range agendamentos {
+		agendamentosDTO = append(agendamentosDTO, *s.entityToResponseDTO(&agendamentos[i], participantes.pet.Nome, participantes.dono.Nome, participantes.petshop.Nome))
+	}
+
+	var dataLimite string
+	if serie.DataLimite != nil {
+		dataLimite = serie.DataLimite.In(participantes.petshop.Localizacao()).Format("2006-01-02")
+	}
+
+	return &dtos.SerieAgendamentoResponseDTO{
+		ID:           serie.ID.String(),
+		DonoID:       serie.DonoID.String(),
+		PetID:        serie.PetID.String(),
+		PetshopID:    serie.PetshopID.String(),
+		Frequencia:   string(serie.Frequencia),
+		DataInicio:   serie.DataInicio.Format(time.RFC3339),
+		DataLimite:   dataLimite,
+		Quantidade:   serie.Quantidade,
+		Cancelada:    serie.Cancelada,
+		Agendamentos: agendamentosDTO,
+		CreatedAt:    serie.CreatedAt.Format(time.RFC3339),
+	}
+}
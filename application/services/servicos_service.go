@@ -34,6 +34,11 @@ func (s *ServicoService) Create(petshopID ksuid.KSUID, dto *dtos.ServicoCreateDT
 		return nil, errors.ErrFailedToCheckPetshop
 	}
 
+	// Validar a duração do serviço
+	if dto.DuracaoMinutos <= 0 || dto.DuracaoMinutos > entities.DuracaoMaximaMinutos {
+		return nil, errors.ErrInvalidServiceDuration
+	}
+
 	// Verificar se já existe um serviço com o mesmo nome neste petshop
 	existingService, err := s.servicoRepository.GetByName(petshopID, dto.Nome)
 	if err != nil {
@@ -45,11 +50,12 @@ func (s *ServicoService) Create(petshopID ksuid.KSUID, dto *dtos.ServicoCreateDT
 
 	// Criar entidade Serviço
 	servico := &entities.Servico{
-		PetshopID: petshopID,
-		Nome:      dto.Nome,
-		Descricao: dto.Descricao,
-		PrecoBase: dto.PrecoBase,
-		Ativo:     true, // Por padrão, o serviço é criado como ativo
+		PetshopID:      petshopID,
+		Nome:           dto.Nome,
+		Descricao:      dto.Descricao,
+		PrecoBase:      dto.PrecoBase,
+		DuracaoMinutos: dto.DuracaoMinutos,
+		Ativo:          true, // Por padrão, o serviço é criado como ativo
 	}
 	// Salvar no repositório
 	if err := s.servicoRepository.Create(servico); err != nil {
@@ -76,6 +82,12 @@ func (s *ServicoService) Update(id ksuid.KSUID, dto *dtos.ServicoUpdateDTO) (*dt
 	if err != nil {
 		return nil, err
 	}
+
+	// Validar a duração do serviço
+	if dto.DuracaoMinutos <= 0 || dto.DuracaoMinutos > entities.DuracaoMaximaMinutos {
+		return nil, errors.ErrInvalidServiceDuration
+	}
+
 	// Verificar se está tentando alterar para um nome que já existe em outro serviço do mesmo petshop
 	if servico.Nome != dto.Nome {
 		existingService, err := s.servicoRepository.GetByName(servico.PetshopID, dto.Nome)
@@ -91,6 +103,7 @@ func (s *ServicoService) Update(id ksuid.KSUID, dto *dtos.ServicoUpdateDTO) (*dt
 	servico.Nome = dto.Nome
 	servico.Descricao = dto.Descricao
 	servico.PrecoBase = dto.PrecoBase
+	servico.DuracaoMinutos = dto.DuracaoMinutos
 	// Salvar no repositório
 	if err := s.servicoRepository.Update(servico); err != nil {
 		return nil, errors.ErrFailedToUpdateService
@@ -133,13 +146,14 @@ func (s *ServicoService) GetByPetshopID(petshopID ksuid.KSUID) ([]dtos.ServicoRe
 // Helper para converter entidade Serviço para DTO
 func (s *ServicoService) entityToDTO(servico *entities.Servico) *dtos.ServicoResponseDTO {
 	return &dtos.ServicoResponseDTO{
-		ID:        servico.ID,
-		PetshopID: servico.PetshopID,
-		Nome:      servico.Nome,
-		Descricao: servico.Descricao,
-		PrecoBase: servico.PrecoBase,
-		Ativo:     servico.Ativo,
-		CreatedAt: servico.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: servico.UpdatedAt.Format(time.RFC3339),
+		ID:             servico.ID,
+		PetshopID:      servico.PetshopID,
+		Nome:           servico.Nome,
+		Descricao:      servico.Descricao,
+		PrecoBase:      servico.PrecoBase,
+		DuracaoMinutos: servico.DuracaoMinutos,
+		Ativo:          servico.Ativo,
+		CreatedAt:      servico.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      servico.UpdatedAt.Format(time.RFC3339),
 	}
 }
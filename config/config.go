@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 )
 
 // Config contém todas as configurações da aplicação
@@ -18,6 +19,12 @@ type Config struct {
 
 	// Configurações de autenticação
 	JWTSecret string
+
+	// Segredo usado para assinar os tickets (QR code) de check-in dos agendamentos
+	TicketSecret string
+
+	// Janela em que as respostas guardadas por Idempotency-Key são reenviadas
+	IdempotencyWindow time.Duration
 }
 
 // LoadConfig carrega as configurações do ambiente
@@ -30,6 +37,10 @@ func LoadConfig() *Config {
 		DBName:     getEnv("DB_NAME", "petshop_db"),
 		ServerPort: getEnv("SERVER_PORT", "8080"),
 		JWTSecret:  getEnv("JWT_SECRET", "chave_secreta_padrao"),
+
+		TicketSecret: getEnv("TICKET_SECRET", "chave_ticket_padrao"),
+
+		IdempotencyWindow: getEnvDuration("IDEMPOTENCY_WINDOW", 24*time.Hour),
 	}
 }
 
@@ -40,3 +51,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvDuration retorna a variável de ambiente interpretada como duração (ex.: "24h", "90m")
+// ou o valor padrão quando ausente ou inválida
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultValue
+}
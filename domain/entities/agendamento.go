@@ -19,34 +19,263 @@ const (
 	StatusCancelado StatusAgendamento = "cancelado"
 	// StatusConcluido é o status quando o procedimento foi realizado
 	StatusConcluido StatusAgendamento = "concluido"
+	// StatusNaoCompareceu é o status quando o dono não levou o pet no horário agendado
+	StatusNaoCompareceu StatusAgendamento = "nao_compareceu"
+)
+
+// StatusQueOcupamAgenda lista os status em que um agendamento ocupa horário na agenda do petshop
+var StatusQueOcupamAgenda = []StatusAgendamento{StatusPendente, StatusConfirmado}
+
+// OcupaAgenda indica se um agendamento com este status ocupa horário na agenda do petshop
+func (s StatusAgendamento) OcupaAgenda() bool {
+	for _, status := range StatusQueOcupamAgenda {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusQuePermitemCheckOut lista os status em que a saída do pet pode ser registrada. Cancelados e faltas
+// ficam de fora para não distorcer a duração real dos atendimentos
+var StatusQuePermitemCheckOut = []StatusAgendamento{StatusConfirmado, StatusConcluido}
+
+// PermiteCheckOut indica se a saída do pet pode ser registrada em um agendamento com este status
+func (s StatusAgendamento) PermiteCheckOut() bool {
+	for _, status := range StatusQuePermitemCheckOut {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// transicoesStatus define, para cada tipo de ator, os status para os quais um agendamento
+// pode evoluir a partir do status atual. Transições ausentes da tabela são proibidas
+var transicoesStatus = map[TipoAtor]map[StatusAgendamento][]StatusAgendamento{
+	// O dono só pode cancelar seus agendamentos ainda não realizados
+	AtorDono: {
+		StatusPendente:   {StatusCancelado},
+		StatusConfirmado: {StatusCancelado},
+	},
+	// O petshop confirma, conclui, cancela ou registra a falta do dono; concluir exige confirmação prévia
+	AtorPetshop: {
+		StatusPendente:   {StatusConfirmado, StatusCancelado, StatusNaoCompareceu},
+		StatusConfirmado: {StatusConcluido, StatusCancelado, StatusNaoCompareceu},
+	},
+}
+
+// PodeTransicionarPara verifica se o ator pode mover o agendamento deste status para o novo status
+func (s StatusAgendamento) PodeTransicionarPara(novo StatusAgendamento, ator TipoAtor) bool {
+	for _, permitido := range transicoesStatus[ator][s] {
+		if permitido == novo {
+			return true
+		}
+	}
+	return false
+}
+
+// TransicaoExiste verifica se algum tipo de ator pode mover o agendamento deste status para o novo status
+func (s StatusAgendamento) TransicaoExiste(novo StatusAgendamento) bool {
+	for ator := range transicoesStatus {
+		if s.PodeTransicionarPara(novo, ator) {
+			return true
+		}
+	}
+	return false
+}
+
+// Valido indica se o status é um dos status de agendamento conhecidos
+func (s StatusAgendamento) Valido() bool {
+	switch s {
+	case StatusPendente, StatusConfirmado, StatusCancelado, StatusConcluido, StatusNaoCompareceu:
+		return true
+	}
+	return false
+}
+
+// Encerrado indica se o status é final, sem transições possíveis
+func (s StatusAgendamento) Encerrado() bool {
+	return s == StatusCancelado || s == StatusConcluido || s == StatusNaoCompareceu
+}
+
+// OrigemAgendamento representa o canal pelo qual o agendamento foi feito
+type OrigemAgendamento string
+
+const (
+	// OrigemApp é o agendamento feito pelo próprio dono
+	OrigemApp OrigemAgendamento = "app"
+	// OrigemTelefone é o agendamento feito pelo petshop a partir de uma ligação
+	OrigemTelefone OrigemAgendamento = "telefone"
+	// OrigemBalcao é o agendamento feito pelo petshop com o cliente presente
+	OrigemBalcao OrigemAgendamento = "balcao"
+)
+
+// ExecucaoItem representa a situação de um serviço do agendamento durante o atendimento
+type ExecucaoItem string
+
+const (
+	// ExecucaoPrevista é a situação inicial, antes de o petshop registrar a execução do serviço
+	ExecucaoPrevista ExecucaoItem = "previsto"
+	// ExecucaoRealizada indica que o serviço foi feito
+	ExecucaoRealizada ExecucaoItem = "executado"
+	// ExecucaoPulada indica que o serviço não foi feito (ex.: pet estressado) e não é cobrado
+	ExecucaoPulada ExecucaoItem = "pulado"
 )
 
 // ItemAgendamento representa um serviço selecionado em um agendamento
 type ItemAgendamento struct {
-	ID            ksuid.KSUID `gorm:"type:varchar(27);primaryKey"`
-	AgendamentoID ksuid.KSUID `gorm:"type:varchar(27);index"`
-	ServicoID     ksuid.KSUID `gorm:"type:varchar(27);index"`
-	NomeServico   string      `gorm:"type:varchar(100);not null"` // Snapshot do nome do serviço
-	PrecoPrevisto float64     `gorm:"type:decimal(10,2);not null"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	ID             ksuid.KSUID `gorm:"type:varchar(27);primaryKey"`
+	AgendamentoID  ksuid.KSUID `gorm:"type:varchar(27);index"`
+	ServicoID      ksuid.KSUID `gorm:"type:varchar(27);index"`
+	PetID          ksuid.KSUID `gorm:"type:varchar(27);index"`     // Pet atendido; vazio indica o pet principal do agendamento
+	NomePet        string      `gorm:"type:varchar(100)"`          // Snapshot do nome do pet
+	NomeServico    string      `gorm:"type:varchar(100);not null"` // Snapshot do nome do serviço
+	PrecoPrevisto  float64     `gorm:"type:decimal(10,2);not null"`
+	DuracaoMinutos int         `gorm:"not null;default:0"` // Snapshot da duração do serviço
+	InicioMinutos  int         `gorm:"not null;default:0"` // Início do serviço, em minutos após o início do agendamento
+
+	// Execução registrada pelo petshop durante o atendimento confirmado
+	Execucao       ExecucaoItem `gorm:"type:varchar(20);not null;default:'previsto'"`
+	Adicionado     bool         `gorm:"not null;default:false"` // Incluído no balcão, fora do que foi agendado
+	PrecoFinal     *float64     `gorm:"type:decimal(10,2)"`     // Preço cobrado; vazio mantém o previsto
+	MotivoExecucao string       `gorm:"type:varchar(500)"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 // Agendamento representa um agendamento de procedimento a ser realizado em um pet
 type Agendamento struct {
 	ID            ksuid.KSUID       `gorm:"type:varchar(27);primaryKey"`
-	DonoID        ksuid.KSUID       `gorm:"type:varchar(27);index;not null"`
-	PetID         ksuid.KSUID       `gorm:"type:varchar(27);index;not null"`
+	DonoID        ksuid.KSUID       `gorm:"type:varchar(27);index"` // Vazio em agendamentos de clientes avulsos
+	PetID         ksuid.KSUID       `gorm:"type:varchar(27);index"` // Primeiro pet; os demais estão nos itens
 	PetshopID     ksuid.KSUID       `gorm:"type:varchar(27);index;not null"`
 	DataAgendada  time.Time         `gorm:"not null;index"`
+	DataFim       time.Time         `gorm:"index"` // Término previsto, calculado a partir da duração dos serviços
 	Status        StatusAgendamento `gorm:"type:varchar(20);not null;default:'pendente'"`
 	Observacoes   string            `gorm:"type:text"`
 	TotalPrevisto float64           `gorm:"type:decimal(10,2);not null"`
 	Itens         []ItemAgendamento `gorm:"foreignKey:AgendamentoID"` // Relação um para muitos
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	SerieID       *ksuid.KSUID      `gorm:"type:varchar(27);index"`   // Série recorrente que gerou o agendamento, se houver
+
+	// Sinaliza agendamentos atingidos por um fechamento do petshop, que precisam ser remarcados
+	RequerRemarcacao bool   `gorm:"not null;default:false"`
+	MotivoRemarcacao string `gorm:"type:varchar(255)"`
+
+	// Dados do cancelamento, preenchidos quando o status passa para cancelado
+	CanceladoEm         *time.Time
+	CancelamentoNoPrazo bool    `gorm:"not null;default:true"`
+	TaxaCancelamento    float64 `gorm:"type:decimal(10,2);not null;default:0"` // Taxa devida pelo dono por cancelamento tardio
+
+	// Donos com muitas faltas precisam confirmar presença antes que o petshop confirme o agendamento
+	ExigeConfirmacaoPresenca bool `gorm:"not null;default:false"`
+	PresencaConfirmadaEm     *time.Time
+
+	// Quantidade de vezes que o agendamento foi remarcado para outra data
+	Remarcacoes int `gorm:"not null;default:0"`
+
+	// Funcionário responsável pelo atendimento, escolhido pelo dono ou atribuído automaticamente
+	FuncionarioID *ksuid.KSUID `gorm:"type:varchar(27);index"`
+
+	// Versão do registro para controle de concorrência otimista, incrementada a cada alteração
+	Versao int `gorm:"not null;default:1"`
+
+	// Canal em que o agendamento foi feito; agendamentos do petshop podem ser de clientes sem conta
+	Origem          OrigemAgendamento `gorm:"type:varchar(20);not null;default:'app'"`
+	ClienteAvulsoID *ksuid.KSUID      `gorm:"type:varchar(27);index"`
+
+	// Chegada, registrada ao ler o ticket (QR code) do agendamento, e saída do pet
+	CheckInEm  *time.Time
+	CheckOutEm *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// DuracaoTotal retorna a soma das durações dos serviços do agendamento
+func (a *Agendamento) DuracaoTotal() time.Duration {
+	var total time.Duration
+	for _, item := range a.Itens {
+		total += time.Duration(item.DuracaoMinutos) * time.Minute
+	}
+	return total
+}
+
+// CalcularDataFim define o término previsto a partir da data agendada e da duração dos itens.
+// Os serviços são realizados em sequência, na ordem dos itens
+func (a *Agendamento) CalcularDataFim() {
+	inicio := 0
+	for i := range a.Itens {
+		a.Itens[i].InicioMinutos = inicio
+		inicio += a.Itens[i].DuracaoMinutos
+	}
+	a.DataFim = a.DataAgendada.Add(a.DuracaoTotal())
+}
+
+// PetDoItem retorna o pet atendido no item. Itens sem pet são do pet principal do agendamento
+func (a *Agendamento) PetDoItem(item *ItemAgendamento) ksuid.KSUID {
+	if item.PetID.IsNil() {
+		return a.PetID
+	}
+	return item.PetID
+}
+
+// PetIDs retorna os pets atendidos no agendamento, na ordem dos itens
+func (a *Agendamento) PetIDs() []ksuid.KSUID {
+	var petIDs []ksuid.KSUID
+	vistos := make(map[ksuid.KSUID]bool)
+	for i := range a.Itens {
+		petID := a.PetDoItem(&a.Itens[i])
+		if !vistos[petID] {
+			vistos[petID] = true
+			petIDs = append(petIDs, petID)
+		}
+	}
+	return petIDs
+}
+
+// QuantidadePets retorna quantos pets o agendamento leva ao petshop, no mínimo um. Todos os pets
+// permanecem no petshop durante o agendamento e ocupam a capacidade de atendimentos simultâneos
+func (a *Agendamento) QuantidadePets() int {
+	if quantidade := len(a.PetIDs()); quantidade > 1 {
+		return quantidade
+	}
+	return 1
+}
+
+// DuracaoReal retorna o tempo entre o check-in e o check-out, quando ambos foram registrados
+func (a *Agendamento) DuracaoReal() (time.Duration, bool) {
+	if a.CheckInEm == nil || a.CheckOutEm == nil {
+		return 0, false
+	}
+	return a.CheckOutEm.Sub(*a.CheckInEm), true
+}
+
+// AtendimentoIniciado indica se o pet já chegou (check-in) ou se o petshop já registrou a execução de algum
+// serviço ou incluiu um serviço no balcão
+func (a *Agendamento) AtendimentoIniciado() bool {
+	if a.CheckInEm != nil {
+		return true
+	}
+	for i := range a.Itens {
+		if a.Itens[i].Execucao != ExecucaoPrevista || a.Itens[i].Adicionado {
+			return true
+		}
+	}
+	return false
+}
+
+// Avulso indica se o agendamento é de um cliente avulso ainda não vinculado a um dono cadastrado
+func (a *Agendamento) Avulso() bool {
+	return a.DonoID.IsNil()
+}
+
+// AguardandoConfirmacaoPresenca indica se o agendamento exige e ainda não recebeu a confirmação de presença do dono
+func (a *Agendamento) AguardandoConfirmacaoPresenca() bool {
+	return a.ExigeConfirmacaoPresenca && a.PresencaConfirmadaEm == nil
 }
 
 // BeforeCreate é chamado pelo GORM antes de criar um registro
@@ -55,11 +284,70 @@ func (a *Agendamento) BeforeCreate(tx *gorm.DB) error {
 	if a.Status == "" {
 		a.Status = StatusPendente
 	}
+	if a.Origem == "" {
+		a.Origem = OrigemApp
+	}
+	if a.Versao == 0 {
+		a.Versao = 1
+	}
 	return nil
 }
 
+// Cobrado indica se o item entra no total final e no procedimento. Serviços pulados não são cobrados
+func (i *ItemAgendamento) Cobrado() bool {
+	return i.Execucao != ExecucaoPulada
+}
+
+// PrecoCobrado retorna o preço final informado pelo petshop ou, na falta dele, o preço previsto
+func (i *ItemAgendamento) PrecoCobrado() float64 {
+	if i.PrecoFinal != nil {
+		return *i.PrecoFinal
+	}
+	return i.PrecoPrevisto
+}
+
+// TotalFinal soma os preços cobrados dos itens não pulados
+func (a *Agendamento) TotalFinal() float64 {
+	var total float64
+	for i := range a.Itens {
+		if a.Itens[i].Cobrado() {
+			total += a.Itens[i].PrecoCobrado()
+		}
+	}
+	return ArredondarPreco(total)
+}
+
 // BeforeCreate é chamado pelo GORM antes de criar um registro
 func (i *ItemAgendamento) BeforeCreate(tx *gorm.DB) error {
 	i.ID = ksuid.New()
+	if i.Execucao == "" {
+		i.Execucao = ExecucaoPrevista
+	}
 	return nil
 }
+
+// CamposOrdenacaoAgendamentos lista os campos aceitos para ordenar as listagens de agendamentos
+var CamposOrdenacaoAgendamentos = []string{"data_agendada", "created_at", "total_previsto", "status"}
+
+// FiltroAgendamentos reúne os critérios de busca, ordenação e paginação das listagens de agendamentos.
+// Critérios vazios não restringem a busca
+type FiltroAgendamentos struct {
+	De        *time.Time // Agendamentos a partir deste instante (inclusivo)
+	Ate       *time.Time // Agendamentos antes deste instante (exclusivo)
+	Status    []StatusAgendamento
+	PetID     *ksuid.KSUID
+	ServicoID *ksuid.KSUID // Agendamentos que incluem o serviço entre os itens
+	Ordenar   string       // Um dos CamposOrdenacaoAgendamentos; padrão data_agendada
+	Crescente bool         // Padrão decrescente (mais recentes primeiro)
+	Pagina    int
+	Limite    int
+}
+
+// AlteracaoAgendamento é a alteração de um agendamento dentro de uma operação em lote, gravada junto com
+// o registro de histórico. Verificar, quando informado (remarcações), recebe os agendamentos que se
+// sobrepõem ao novo período e os fechamentos do petshop, e é executado na mesma transação da escrita
+type AlteracaoAgendamento struct {
+	Agendamento *Agendamento
+	Historico   *HistoricoAgendamento
+	Verificar   func(sobrepostos []Agendamento, fechamentos []FechamentoPetshop) error
+}
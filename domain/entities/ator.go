@@ -0,0 +1,19 @@
+package entities
+
+import "github.com/segmentio/ksuid"
+
+// TipoAtor identifica o tipo de usuário que executa uma ação, conforme a claim "tipo" do JWT
+type TipoAtor string
+
+const (
+	// AtorDono representa um dono de pet autenticado
+	AtorDono TipoAtor = "dono"
+	// AtorPetshop representa um petshop autenticado
+	AtorPetshop TipoAtor = "petshop"
+)
+
+// Ator representa o usuário autenticado que executa uma ação
+type Ator struct {
+	ID   ksuid.KSUID
+	Tipo TipoAtor
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// ChaveIdempotencia guarda a primeira resposta de uma requisição de criação enviada com o cabeçalho
+// Idempotency-Key, para que novas tentativas do mesmo usuário com a mesma chave recebam a mesma resposta
+type ChaveIdempotencia struct {
+	ID        ksuid.KSUID `gorm:"type:varchar(27);primaryKey" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	UsuarioID  string    `gorm:"type:varchar(27);not null;uniqueIndex:idx_chave_idempotencia_usuario"`
+	Chave      string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_chave_idempotencia_usuario"`
+	Rota       string    `gorm:"type:varchar(255);not null"` // Método e caminho da requisição original
+	HashCorpo  string    `gorm:"type:varchar(64);not null"`  // SHA-256 do corpo da requisição original
+	StatusCode int       `gorm:"not null;default:0"`         // Zero enquanto a requisição original está em andamento
+	Resposta   []byte    `gorm:"type:bytea"`
+	ExpiraEm   time.Time `gorm:"index;not null"`
+}
+
+// EmAndamento indica se a requisição original ainda não terminou
+func (c *ChaveIdempotencia) EmAndamento() bool {
+	return c.StatusCode == 0
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (c *ChaveIdempotencia) BeforeCreate(tx *gorm.DB) error {
+	c.ID = ksuid.New()
+	return nil
+}
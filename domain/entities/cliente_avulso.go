@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// ClienteAvulso representa um cliente sem conta atendido pelo petshop (por telefone ou no balcão) e o seu pet.
+// Quando o petshop confirma que a pessoa se cadastrou como dono, com o mesmo email ou telefone, o cliente
+// é vinculado ao dono e o pet passa a ser um pet cadastrado do dono
+type ClienteAvulso struct {
+	ID        ksuid.KSUID `gorm:"type:varchar(27);primaryKey" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PetshopID  ksuid.KSUID `gorm:"type:varchar(27);index;not null"`
+	Nome       string      `gorm:"type:varchar(100);not null"`
+	Email      string      `gorm:"type:varchar(255);index"` // Minúsculo
+	Telefone   string      `gorm:"type:varchar(20);index"`  // Apenas dígitos
+	NomePet    string      `gorm:"type:varchar(100);not null"`
+	EspeciePet string      `gorm:"type:varchar(50);not null"`
+	RacaPet    string      `gorm:"type:varchar(50)"`
+
+	// Preenchidos quando o cliente é vinculado a um dono cadastrado
+	DonoID      *ksuid.KSUID `gorm:"type:varchar(27);index"`
+	PetID       *ksuid.KSUID `gorm:"type:varchar(27)"`
+	VinculadoEm *time.Time
+}
+
+// NormalizarEmail padroniza o email para comparação entre clientes avulsos e donos
+func NormalizarEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizarTelefone mantém apenas os dígitos do telefone para comparação entre clientes avulsos e donos
+func NormalizarTelefone(telefone string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, telefone)
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (c *ClienteAvulso) BeforeCreate(tx *gorm.DB) error {
+	c.ID = ksuid.New()
+	return nil
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// ConfiabilidadeDono resume o histórico de não comparecimentos de um dono, em todos os petshops
+type ConfiabilidadeDono struct {
+	DonoID                  ksuid.KSUID
+	NaoComparecimentos      int
+	UltimoNaoComparecimento *time.Time
+}
+
+// ExigeConfirmacaoPresenca indica se o petshop exige que o dono confirme presença antes que o
+// agendamento possa ser confirmado, conforme o limite de faltas configurado (0 desativa a exigência)
+func (p *Petshop) ExigeConfirmacaoPresenca(confiabilidade ConfiabilidadeDono) bool {
+	return p.LimiteFaltasConfirmacao > 0 && confiabilidade.NaoComparecimentos >= p.LimiteFaltasConfirmacao
+}
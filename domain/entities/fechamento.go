@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// Periodo representa um intervalo de tempo [Inicio, Fim)
+type Periodo struct {
+	Inicio time.Time
+	Fim    time.Time
+}
+
+// FechamentoPetshop representa um período em que o petshop não atende (feriado, reforma, férias da equipe).
+// Fechamentos de dia inteiro podem se repetir anualmente, como feriados de data fixa
+type FechamentoPetshop struct {
+	ID        ksuid.KSUID `gorm:"type:varchar(27);primaryKey" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PetshopID       ksuid.KSUID `json:"petshop_id" gorm:"type:varchar(27);index;not null"`
+	Inicio          time.Time   `json:"inicio" gorm:"not null;index"`
+	Fim             time.Time   `json:"fim" gorm:"not null;index"` // Exclusivo
+	DiaInteiro      bool        `json:"dia_inteiro" gorm:"not null;default:false"`
+	RecorrenteAnual bool        `json:"recorrente_anual" gorm:"not null;default:false"`
+	Motivo          string      `json:"motivo" gorm:"type:varchar(200)"`
+}
+
+// OcorrenciasEntre retorna os períodos concretos do fechamento que se sobrepõem a [inicio, fim).
+// Fechamentos recorrentes são projetados para cada ano do intervalo, no fuso horário informado, a partir
+// do ano em que foram cadastrados. Datas em 29 de fevereiro caem em 28 de fevereiro nos anos não bissextos
+func (f *FechamentoPetshop) OcorrenciasEntre(inicio, fim time.Time, loc *time.Location) []Periodo {
+	if !f.RecorrenteAnual {
+		if f.Inicio.Before(fim) && f.Fim.After(inicio) {
+			return []Periodo{{Inicio: f.Inicio, Fim: f.Fim}}
+		}
+		return nil
+	}
+
+	var ocorrencias []Periodo
+	base := f.Inicio.In(loc)
+	baseFim := f.Fim.In(loc)
+	primeiroAno := inicio.In(loc).Year() - 1
+	if primeiroAno < base.Year() {
+		primeiroAno = base.Year()
+	}
+	for ano := primeiroAno; ano <= fim.In(loc).Year(); ano++ {
+		deslocamento := ano - base.Year()
+		ocorrencia := Periodo{
+			Inicio: deslocarAnos(base, deslocamento),
+			Fim:    deslocarAnos(baseFim, deslocamento),
+		}
+		if ocorrencia.Inicio.Before(fim) && ocorrencia.Fim.After(inicio) {
+			ocorrencias = append(ocorrencias, ocorrencia)
+		}
+	}
+	return ocorrencias
+}
+
+// deslocarAnos move a data o número de anos informado mantendo o dia e o horário. Ao contrário de AddDate,
+// 29 de fevereiro vira 28 de fevereiro (e não 1º de março) quando o ano de destino não é bissexto
+func deslocarAnos(t time.Time, anos int) time.Time {
+	ano := t.Year() + anos
+	dia := t.Day()
+	if t.Month() == time.February && dia == 29 && !anoBissexto(ano) {
+		dia = 28
+	}
+	return time.Date(ano, t.Month(), dia, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// anoBissexto verifica se o ano tem 29 de fevereiro
+func anoBissexto(ano int) bool {
+	return ano%4 == 0 && (ano%100 != 0 || ano%400 == 0)
+}
+
+// SobrepoeA verifica se alguma ocorrência do fechamento se sobrepõe ao período [inicio, fim)
+func (f *FechamentoPetshop) SobrepoeA(inicio, fim time.Time, loc *time.Location) bool {
+	return len(f.OcorrenciasEntre(inicio, fim, loc)) > 0
+}
+
+// Antes de criar um registro o ID é gerado automaticamente
+func (f *FechamentoPetshop) BeforeCreate(tx *gorm.DB) error {
+	id, err := ksuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	f.ID = id
+	return nil
+}
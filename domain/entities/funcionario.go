@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// Funcionario representa um profissional do petshop (tosador, banhista, veterinário)
+// e os serviços que ele está habilitado a realizar
+type Funcionario struct {
+	ID        ksuid.KSUID `gorm:"type:varchar(27);primaryKey" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PetshopID ksuid.KSUID `json:"petshop_id" gorm:"type:varchar(27);index;not null"`
+	Nome      string      `json:"nome" gorm:"type:varchar(100);not null"`
+	Ativo     bool        `json:"ativo" gorm:"not null;default:true"`
+	Servicos  []Servico   `json:"servicos" gorm:"many2many:funcionario_servicos;"`
+}
+
+// PodeRealizar verifica se o funcionário está habilitado para todos os serviços informados
+func (f *Funcionario) PodeRealizar(servicoIDs []ksuid.KSUID) bool {
+	habilitados := make(map[ksuid.KSUID]bool, len(f.Servicos))
+	for _, servico := range f.Servicos {
+		habilitados[servico.ID] = true
+	}
+	for _, servicoID := range servicoIDs {
+		if !habilitados[servicoID] {
+			return false
+		}
+	}
+	return true
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (f *Funcionario) BeforeCreate(tx *gorm.DB) error {
+	f.ID = ksuid.New()
+	return nil
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// HistoricoAgendamento registra uma mudança de status ou uma remarcação de um agendamento: quem fez, quando e por quê
+type HistoricoAgendamento struct {
+	ID             ksuid.KSUID       `gorm:"type:varchar(27);primaryKey"`
+	AgendamentoID  ksuid.KSUID       `gorm:"type:varchar(27);index;not null"`
+	StatusAnterior StatusAgendamento `gorm:"type:varchar(20);not null"`
+	StatusNovo     StatusAgendamento `gorm:"type:varchar(20);not null"`
+	AtorID         ksuid.KSUID       `gorm:"type:varchar(27);not null"`
+	AtorTipo       TipoAtor          `gorm:"type:varchar(20);not null"`
+	Motivo         string            `gorm:"type:text"`
+	CreatedAt      time.Time         `gorm:"index"`
+
+	// Datas antes e depois da remarcação, preenchidas apenas em remarcações
+	DataAnterior *time.Time
+	DataNova     *time.Time
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (h *HistoricoAgendamento) BeforeCreate(tx *gorm.DB) error {
+	h.ID = ksuid.New()
+	return nil
+}
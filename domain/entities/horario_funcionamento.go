@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// MinutosPorDia é o limite superior de um intervalo de funcionamento (24:00)
+const MinutosPorDia = 24 * 60
+
+// HorarioFuncionamento representa um intervalo de atendimento de um petshop em um dia da semana.
+// Um mesmo dia pode ter vários intervalos (ex.: manhã e tarde, com pausa para o almoço)
+type HorarioFuncionamento struct {
+	ID        ksuid.KSUID `gorm:"type:varchar(27);primaryKey" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PetshopID     ksuid.KSUID  `json:"petshop_id" gorm:"type:varchar(27);index;not null"`
+	DiaSemana     time.Weekday `json:"dia_semana" gorm:"not null"`     // 0 = domingo ... 6 = sábado
+	InicioMinutos int          `json:"inicio_minutos" gorm:"not null"` // Minutos desde a meia-noite
+	FimMinutos    int          `json:"fim_minutos" gorm:"not null"`    // Minutos desde a meia-noite (exclusivo)
+}
+
+// Contem verifica se o período [inicioMinutos, fimMinutos) do mesmo dia está dentro do intervalo
+func (h *HorarioFuncionamento) Contem(inicioMinutos, fimMinutos int) bool {
+	return inicioMinutos >= h.InicioMinutos && fimMinutos <= h.FimMinutos
+}
+
+// Antes de criar um registro o ID é gerado automaticamente
+func (h *HorarioFuncionamento) BeforeCreate(tx *gorm.DB) error {
+	id, err := ksuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	h.ID = id
+	return nil
+}
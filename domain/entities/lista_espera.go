@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// StatusListaEspera representa a situação de uma entrada na lista de espera
+type StatusListaEspera string
+
+const (
+	// StatusEsperaAguardando é o status da entrada aguardando um horário ser liberado
+	StatusEsperaAguardando StatusListaEspera = "aguardando"
+	// StatusEsperaOfertada é o status da entrada que recebeu um horário e o mantém reservado até a oferta expirar
+	StatusEsperaOfertada StatusListaEspera = "ofertada"
+	// StatusEsperaAceita é o status da entrada cuja oferta virou um agendamento
+	StatusEsperaAceita StatusListaEspera = "aceita"
+	// StatusEsperaRecusada é o status da entrada cujo dono recusou a oferta
+	StatusEsperaRecusada StatusListaEspera = "recusada"
+	// StatusEsperaExpirada é o status da entrada cuja oferta ou janela de datas expirou
+	StatusEsperaExpirada StatusListaEspera = "expirada"
+	// StatusEsperaCancelada é o status da entrada retirada da lista pelo dono
+	StatusEsperaCancelada StatusListaEspera = "cancelada"
+)
+
+// PrazoReservaListaEspera é quanto tempo um horário ofertado fica reservado para a entrada
+const PrazoReservaListaEspera = 30 * time.Minute
+
+// ItemListaEspera representa um serviço desejado em uma entrada da lista de espera
+type ItemListaEspera struct {
+	ID             ksuid.KSUID `gorm:"type:varchar(27);primaryKey"`
+	EntradaID      ksuid.KSUID `gorm:"type:varchar(27);index"`
+	ServicoID      ksuid.KSUID `gorm:"type:varchar(27);index"`
+	NomeServico    string      `gorm:"type:varchar(100);not null"` // Snapshot do nome do serviço
+	PrecoPrevisto  float64     `gorm:"type:decimal(10,2);not null"`
+	DuracaoMinutos int         `gorm:"not null;default:0"` // Snapshot da duração do serviço
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// EntradaListaEspera representa um dono aguardando um horário em um petshop dentro de uma janela de datas.
+// Quando um agendamento sobreposto é cancelado, a primeira entrada compatível recebe a oferta do horário
+type EntradaListaEspera struct {
+	ID            ksuid.KSUID       `gorm:"type:varchar(27);primaryKey"`
+	DonoID        ksuid.KSUID       `gorm:"type:varchar(27);index;not null"`
+	PetID         ksuid.KSUID       `gorm:"type:varchar(27);index;not null"`
+	PetshopID     ksuid.KSUID       `gorm:"type:varchar(27);index;not null"`
+	JanelaInicio  time.Time         `gorm:"not null"` // Início mais cedo aceito pelo dono
+	JanelaFim     time.Time         `gorm:"not null"` // Término mais tarde aceito pelo dono
+	Status        StatusListaEspera `gorm:"type:varchar(20);not null;default:'aguardando';index"`
+	Observacoes   string            `gorm:"type:text"`
+	TotalPrevisto float64           `gorm:"type:decimal(10,2);not null"`
+	Itens         []ItemListaEspera `gorm:"foreignKey:EntradaID"` // Relação um para muitos
+
+	// Horário ofertado e prazo da reserva, enquanto a entrada está ofertada
+	OfertaInicio   *time.Time
+	OfertaFim      *time.Time
+	OfertaExpiraEm *time.Time `gorm:"index"`
+
+	// Agendamento criado quando a oferta é aceita
+	AgendamentoID *ksuid.KSUID `gorm:"type:varchar(27)"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// Duracao retorna a soma das durações dos serviços desejados
+func (e *EntradaListaEspera) Duracao() time.Duration {
+	var total time.Duration
+	for _, item := range e.Itens {
+		total += time.Duration(item.DuracaoMinutos) * time.Minute
+	}
+	return total
+}
+
+// Reserva representa o horário ofertado como um agendamento, para que ele ocupe a agenda do
+// petshop durante o prazo da reserva. O ID da reserva é o ID da entrada
+func (e *EntradaListaEspera) Reserva() Agendamento {
+	reserva := Agendamento{
+		ID:           e.ID,
+		DonoID:       e.DonoID,
+		PetID:        e.PetID,
+		PetshopID:    e.PetshopID,
+		DataAgendada: *e.OfertaInicio,
+		DataFim:      *e.OfertaFim,
+		Status:       StatusPendente,
+	}
+
+	// Os itens permitem calcular os recursos físicos ocupados pela reserva
+	inicio := 0
+	for _, item := range e.Itens {
+		reserva.Itens = append(reserva.Itens, ItemAgendamento{
+			ServicoID:      item.ServicoID,
+			DuracaoMinutos: item.DuracaoMinutos,
+			InicioMinutos:  inicio,
+		})
+		inicio += item.DuracaoMinutos
+	}
+	return reserva
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (e *EntradaListaEspera) BeforeCreate(tx *gorm.DB) error {
+	e.ID = ksuid.New()
+	if e.Status == "" {
+		e.Status = StatusEsperaAguardando
+	}
+	return nil
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (i *ItemListaEspera) BeforeCreate(tx *gorm.DB) error {
+	i.ID = ksuid.New()
+	return nil
+}
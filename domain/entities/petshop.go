@@ -27,8 +27,22 @@ type Petshop struct {
 	Descricao   string    `json:"descricao"`
 	Nota        float32   `json:"nota"`
 	Ativo       bool      `json:"ativo"`
+	Capacidade  int       `json:"capacidade" gorm:"not null;default:1"` // Atendimentos simultâneos suportados
+	FusoHorario string    `json:"fuso_horario" gorm:"not null;default:'America/Sao_Paulo'"`
 	Servicos    []Servico `json:"servicos" gorm:"foreignKey:PetshopID"`
 	Password    string    `json:"-" gorm:"not null"`
+
+	// Política de cancelamento: cancelamentos do dono a menos de PrazoCancelamentoHoras do horário agendado
+	// são tardios e geram taxa conforme TipoTaxaCancelamento
+	PrazoCancelamentoHoras int                  `json:"prazo_cancelamento_horas" gorm:"not null;default:0"`
+	TipoTaxaCancelamento   TipoTaxaCancelamento `json:"tipo_taxa_cancelamento" gorm:"type:varchar(20);not null;default:'nenhuma'"`
+	ValorTaxaCancelamento  float64              `json:"valor_taxa_cancelamento" gorm:"type:decimal(10,2);not null;default:0"`
+
+	// Donos com ao menos LimiteFaltasConfirmacao não comparecimentos precisam confirmar presença (0 desativa)
+	LimiteFaltasConfirmacao int `json:"limite_faltas_confirmacao" gorm:"not null;default:0"`
+
+	// Agendamentos confirmados remarcados pelo dono voltam a pendente e precisam ser confirmados de novo
+	ExigeReconfirmacaoRemarcacao bool `json:"exige_reconfirmacao_remarcacao" gorm:"not null;default:false"`
 }
 
 // Antes de criar um registro o ID é gerado automaticamente
@@ -44,6 +58,22 @@ func (d *Petshop) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// FusoHorarioPadrao é o fuso usado quando o petshop não informa um fuso válido
+const FusoHorarioPadrao = "America/Sao_Paulo"
+
+// Localizacao retorna o fuso horário em que o horário de funcionamento do petshop é interpretado
+func (p *Petshop) Localizacao() *time.Location {
+	if p.FusoHorario != "" {
+		if loc, err := time.LoadLocation(p.FusoHorario); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(FusoHorarioPadrao); err == nil {
+		return loc
+	}
+	return time.FixedZone("BRT", -3*60*60)
+}
+
 // SetPassword gera um hash da senha para armazenamento seguro
 func (p *Petshop) SetPassword(password string) error {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// TipoTaxaCancelamento define como a taxa de cancelamento tardio é calculada
+type TipoTaxaCancelamento string
+
+const (
+	// TaxaCancelamentoNenhuma não cobra taxa, mesmo fora do prazo
+	TaxaCancelamentoNenhuma TipoTaxaCancelamento = "nenhuma"
+	// TaxaCancelamentoFixa cobra um valor fixo, limitado ao total previsto do agendamento
+	TaxaCancelamentoFixa TipoTaxaCancelamento = "fixa"
+	// TaxaCancelamentoPercentual cobra um percentual do total previsto do agendamento
+	TaxaCancelamentoPercentual TipoTaxaCancelamento = "percentual"
+)
+
+// AvaliarCancelamento aplica a política de cancelamento do petshop a um cancelamento feito pelo dono
+// no instante informado. Retorna se o cancelamento ocorreu dentro do prazo e a taxa devida
+func (p *Petshop) AvaliarCancelamento(agendamento *Agendamento, em time.Time) (noPrazo bool, taxa float64) {
+	limite := agendamento.DataAgendada.Add(-time.Duration(p.PrazoCancelamentoHoras) * time.Hour)
+	if !em.After(limite) {
+		return true, 0
+	}
+
+	switch p.TipoTaxaCancelamento {
+	case TaxaCancelamentoFixa:
+		taxa = math.Min(p.ValorTaxaCancelamento, agendamento.TotalPrevisto)
+	case TaxaCancelamentoPercentual:
+		taxa = agendamento.TotalPrevisto * p.ValorTaxaCancelamento / 100
+	}
+	return false, math.Round(taxa*100) / 100
+}
@@ -24,7 +24,8 @@ type Procedimento struct {
 	ID             ksuid.KSUID        `gorm:"type:varchar(27);primaryKey"`
 	PetID          ksuid.KSUID        `gorm:"type:varchar(27);index;not null"`
 	PetshopID      ksuid.KSUID        `gorm:"type:varchar(27);index;not null"`
-	NomePetshop    string             `gorm:"type:varchar(100);not null"` // Snapshot do nome do petshop
+	AgendamentoID  *ksuid.KSUID       `gorm:"type:varchar(27);uniqueIndex"` // Agendamento de origem, quando gerado pela conclusão de um agendamento
+	NomePetshop    string             `gorm:"type:varchar(100);not null"`   // Snapshot do nome do petshop
 	DataRealizacao time.Time          `gorm:"not null"`
 	Observacoes    string             `gorm:"type:text"`
 	Total          float64            `gorm:"type:decimal(10,2);not null"`
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// Recurso representa um recurso físico do petshop (banheira, mesa de tosa, baia) disponível
+// em quantidade limitada, que restringe quantos atendimentos podem usá-lo ao mesmo tempo
+type Recurso struct {
+	ID        ksuid.KSUID `gorm:"type:varchar(27);primaryKey" json:"id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PetshopID  ksuid.KSUID      `json:"petshop_id" gorm:"type:varchar(27);index;not null"`
+	Nome       string           `json:"nome" gorm:"type:varchar(100);not null"`
+	Quantidade int              `json:"quantidade" gorm:"not null;default:1"`
+	Consumos   []ConsumoRecurso `json:"consumos" gorm:"foreignKey:RecursoID"`
+}
+
+// ConsumoRecurso indica que um serviço ocupa uma unidade do recurso a partir do início do
+// serviço, durante os minutos informados
+type ConsumoRecurso struct {
+	ID             ksuid.KSUID `gorm:"type:varchar(27);primaryKey"`
+	RecursoID      ksuid.KSUID `gorm:"type:varchar(27);index;not null"`
+	ServicoID      ksuid.KSUID `gorm:"type:varchar(27);index;not null"`
+	DuracaoMinutos int         `gorm:"not null;default:0"` // Zero: o recurso fica ocupado durante todo o serviço
+	CreatedAt      time.Time
+}
+
+// Duracao retorna por quanto tempo o recurso fica ocupado em um serviço com a duração informada
+func (c *ConsumoRecurso) Duracao(duracaoServico time.Duration) time.Duration {
+	duracao := time.Duration(c.DuracaoMinutos) * time.Minute
+	if duracao <= 0 || duracao > duracaoServico {
+		return duracaoServico
+	}
+	return duracao
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (r *Recurso) BeforeCreate(tx *gorm.DB) error {
+	r.ID = ksuid.New()
+	return nil
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (c *ConsumoRecurso) BeforeCreate(tx *gorm.DB) error {
+	c.ID = ksuid.New()
+	return nil
+}
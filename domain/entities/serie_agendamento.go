@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// FrequenciaSerie representa a periodicidade de uma série de agendamentos recorrentes
+type FrequenciaSerie string
+
+const (
+	// FrequenciaSemanal repete o agendamento a cada 7 dias
+	FrequenciaSemanal FrequenciaSerie = "semanal"
+	// FrequenciaQuinzenal repete o agendamento a cada 14 dias
+	FrequenciaQuinzenal FrequenciaSerie = "quinzenal"
+	// FrequenciaMensal repete o agendamento no mesmo dia de cada mês
+	// (ou no último dia, em meses mais curtos)
+	FrequenciaMensal FrequenciaSerie = "mensal"
+)
+
+// MaxOcorrenciasSerie limita quantos agendamentos uma série pode materializar
+const MaxOcorrenciasSerie = 52
+
+// SerieAgendamento representa uma regra de recorrência que gera agendamentos individuais antecipadamente
+type SerieAgendamento struct {
+	ID         ksuid.KSUID     `gorm:"type:varchar(27);primaryKey"`
+	DonoID     ksuid.KSUID     `gorm:"type:varchar(27);index;not null"`
+	PetID      ksuid.KSUID     `gorm:"type:varchar(27);index;not null"`
+	PetshopID  ksuid.KSUID     `gorm:"type:varchar(27);index;not null"`
+	Frequencia FrequenciaSerie `gorm:"type:varchar(20);not null"`
+	DataInicio time.Time       `gorm:"not null"` // Primeira ocorrência
+	DataLimite *time.Time      // Última data possível (opcional se Quantidade for informada)
+	Quantidade int             `gorm:"not null;default:0"` // Número de ocorrências (opcional se DataLimite for informada)
+	Cancelada  bool            `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
+
+// Ocorrencias calcula as datas dos agendamentos da série no fuso horário do petshop,
+// preservando o horário local mesmo em mudanças de horário de verão
+func (s *SerieAgendamento) Ocorrencias(loc *time.Location) []time.Time {
+	inicio := s.DataInicio.In(loc)
+
+	var datas []time.Time
+	for i := 0; len(datas) < MaxOcorrenciasSerie; i++ {
+		if s.Quantidade > 0 && len(datas) >= s.Quantidade {
+			break
+		}
+
+		var data time.Time
+		switch s.Frequencia {
+		case FrequenciaSemanal:
+			data = time.Date(inicio.Year(), inicio.Month(), inicio.Day()+7*i, inicio.Hour(), inicio.Minute(), 0, 0, loc)
+		case FrequenciaQuinzenal:
+			data = time.Date(inicio.Year(), inicio.Month(), inicio.Day()+14*i, inicio.Hour(), inicio.Minute(), 0, 0, loc)
+		case FrequenciaMensal:
+			// Meses sem o dia de início (ex.: 31) usam o último dia do mês
+			dia := inicio.Day()
+			ultimoDia := time.Date(inicio.Year(), inicio.Month()+time.Month(i)+1, 0, 0, 0, 0, 0, loc).Day()
+			if dia > ultimoDia {
+				dia = ultimoDia
+			}
+			data = time.Date(inicio.Year(), inicio.Month()+time.Month(i), dia, inicio.Hour(), inicio.Minute(), 0, 0, loc)
+		default:
+			return nil
+		}
+
+		if s.DataLimite != nil && data.After(*s.DataLimite) {
+			break
+		}
+		datas = append(datas, data)
+	}
+	return datas
+}
+
+// BeforeCreate é chamado pelo GORM antes de criar um registro
+func (s *SerieAgendamento) BeforeCreate(tx *gorm.DB) error {
+	s.ID = ksuid.New()
+	return nil
+}
@@ -1,6 +1,9 @@
 package entities
 
 import (
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/segmentio/ksuid"
@@ -13,13 +16,53 @@ type Servico struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
-	Nome      string      `json:"nome" gorm:"not null"`
-	Descricao string      `json:"descricao"`
-	Preco     float32     `json:"preco" gorm:"not null"`
-	Duracao   string      `json:"duracao"`
-	PetshopID ksuid.KSUID `json:"petshop_id" gorm:"type:varchar(27);not null"`
-	PrecoBase float64     `json:"preco_base" gorm:"not null"`
-	Ativo     bool        `json:"ativo" gorm:"default:true"`
+	Nome           string      `json:"nome" gorm:"not null"`
+	Descricao      string      `json:"descricao"`
+	Preco          float32     `json:"preco" gorm:"not null"`
+	DuracaoMinutos int         `json:"duracao_minutos" gorm:"not null;default:30"`
+	PetshopID      ksuid.KSUID `json:"petshop_id" gorm:"type:varchar(27);not null"`
+	PrecoBase      float64     `json:"preco_base" gorm:"not null"`
+	Ativo          bool        `json:"ativo" gorm:"default:true"`
+}
+
+// DuracaoPadraoMinutos é a duração assumida para serviços sem duração cadastrada
+const DuracaoPadraoMinutos = 30
+
+// DuracaoMaximaMinutos é a maior duração aceita para um serviço (um dia)
+const DuracaoMaximaMinutos = 24 * 60
+
+// Duracao retorna a duração do serviço, usando a duração padrão quando não cadastrada
+func (s *Servico) Duracao() time.Duration {
+	if s.DuracaoMinutos <= 0 {
+		return DuracaoPadraoMinutos * time.Minute
+	}
+	return time.Duration(s.DuracaoMinutos) * time.Minute
+}
+
+// PrecoAgendamento retorna o preço cobrado pelo serviço em um agendamento, calculado a partir do
+// preço base cadastrado pelo petshop e arredondado para centavos
+func (s *Servico) PrecoAgendamento() float64 {
+	return ArredondarPreco(s.PrecoBase)
+}
+
+// ArredondarPreco arredonda um valor monetário para centavos
+func ArredondarPreco(valor float64) float64 {
+	return math.Round(valor*100) / 100
+}
+
+// ParseDuracaoMinutos interpreta a duração em texto livre usada antes de DuracaoMinutos
+// ("90", "45min", "1h30m"). Retorna false quando o valor não pode ser interpretado
+func ParseDuracaoMinutos(duracao string) (int, bool) {
+	duracao = strings.ToLower(strings.TrimSpace(duracao))
+	duracao = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(duracao, "minutos"), "min"))
+
+	if minutos, err := strconv.Atoi(duracao); err == nil && minutos > 0 {
+		return minutos, true
+	}
+	if d, err := time.ParseDuration(duracao); err == nil && d >= time.Minute {
+		return int(d / time.Minute), true
+	}
+	return 0, false
 }
 
 // Antes de criar um registro o ID é gerado automaticamente
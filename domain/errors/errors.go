@@ -40,6 +40,7 @@ var (
 	ErrUpdatePetshop         = errors.New("falha ao atualizar petshop")
 	ErrUpdatePetshopLocation = errors.New("falha ao atualizar localização do petshop")
 	ErrPetshopNotFound       = errors.New("petshop não encontrado")
+	ErrInvalidCancelPolicy   = errors.New("política de cancelamento inválida: a taxa percentual não pode passar de 100%")
 )
 
 // Erros relacionados a Pet
@@ -53,20 +54,73 @@ var (
 
 // Erros relacionados a Serviço
 var (
-	ErrFailedToCheckService  = errors.New("falha ao verificar serviço existente")
-	ErrFailedToCreateService = errors.New("falha ao criar serviço")
-	ErrFailedToUpdateService = errors.New("falha ao atualizar serviço")
-	ErrFailedToFetchServices = errors.New("falha ao buscar serviços")
-	ErrServiceNotFound       = errors.New("serviço não encontrado")
-	ErrServiceNotFromPetshop = errors.New("o serviço não pertence ao petshop informado")
+	ErrFailedToCheckService   = errors.New("falha ao verificar serviço existente")
+	ErrFailedToCreateService  = errors.New("falha ao criar serviço")
+	ErrFailedToUpdateService  = errors.New("falha ao atualizar serviço")
+	ErrFailedToFetchServices  = errors.New("falha ao buscar serviços")
+	ErrServiceNotFound        = errors.New("serviço não encontrado")
+	ErrServiceNotFromPetshop  = errors.New("o serviço não pertence ao petshop informado")
+	ErrInvalidServiceDuration = errors.New("a duração do serviço deve estar entre 1 minuto e 24 horas")
 )
 
 // Erros relacionados a Procedimento
 var (
-	ErrInvalidDate            = errors.New("formato de data inválido, use ISO 8601")
-	ErrFutureDate             = errors.New("a data de realização não pode ser futura")
-	ErrTotalMismatch          = errors.New("o total informado não corresponde à soma dos preços finais")
-	ErrFailedToCheckProcedure = errors.New("falha ao verificar procedimento")
+	ErrInvalidDate             = errors.New("formato de data inválido, use ISO 8601")
+	ErrFutureDate              = errors.New("a data de realização não pode ser futura")
+	ErrTotalMismatch           = errors.New("o total informado não corresponde à soma dos preços finais")
+	ErrFailedToCheckProcedure  = errors.New("falha ao verificar procedimento")
+	ErrFailedToCreateProcedure = errors.New("falha ao registrar procedimento")
+)
+
+// Erros relacionados a Funcionário
+var (
+	ErrFuncionarioNotFound       = errors.New("funcionário não encontrado")
+	ErrFuncionarioNotFromPetshop = errors.New("o funcionário não pertence ao petshop informado")
+	ErrFuncionarioInactive       = errors.New("o funcionário não está ativo")
+	ErrFuncionarioCannotPerform  = errors.New("o funcionário não realiza todos os serviços do agendamento")
+	ErrFuncionarioIndisponivel   = errors.New("o funcionário já possui agendamento no horário solicitado")
+	ErrNoStaffAvailable          = errors.New("nenhum funcionário habilitado para os serviços está disponível no horário solicitado")
+	ErrInvalidAgendaPeriod       = errors.New("período da agenda inválido: informe de e ate (AAAA-MM-DD) com até 31 dias")
+	ErrFailedToFetchStaff        = errors.New("falha ao buscar funcionários")
+	ErrFailedToCreateStaff       = errors.New("falha ao cadastrar funcionário")
+	ErrFailedToUpdateStaff       = errors.New("falha ao atualizar funcionário")
+	ErrFailedToDeleteStaff       = errors.New("falha ao excluir funcionário")
+)
+
+// Erros relacionados a Recursos físicos
+var (
+	ErrRecursoNotFound         = errors.New("recurso não encontrado")
+	ErrRecursoNotFromPetshop   = errors.New("o recurso não pertence ao petshop informado")
+	ErrRecursoIndisponivel     = errors.New("recurso físico do petshop indisponível no horário solicitado")
+	ErrInvalidResourceQuantity = errors.New("a quantidade do recurso deve ser de pelo menos 1")
+	ErrInvalidResourceUsage    = errors.New("a duração do consumo deve estar entre 1 minuto e a duração do serviço")
+	ErrFailedToFetchResources  = errors.New("falha ao buscar recursos")
+	ErrFailedToCreateResource  = errors.New("falha ao cadastrar recurso")
+	ErrFailedToUpdateResource  = errors.New("falha ao atualizar recurso")
+	ErrFailedToDeleteResource  = errors.New("falha ao excluir recurso")
+)
+
+// Erros relacionados a Clientes avulsos
+var (
+	ErrInvalidBookingCustomer    = errors.New("informe dono_id e pet_id de um dono cadastrado ou os dados do cliente (com email ou telefone), não ambos")
+	ErrClienteAvulsoJaCadastrado = errors.New("já existe um dono cadastrado com este email; agende informando dono_id e pet_id")
+	ErrFailedToFetchGuest        = errors.New("falha ao buscar cliente avulso")
+	ErrGuestAlreadyLinked        = errors.New("o cliente avulso já foi vinculado a um dono")
+	ErrGuestContactMismatch      = errors.New("o email e o telefone do dono não correspondem aos do cliente avulso")
+	ErrFailedToLinkGuest         = errors.New("falha ao vincular cliente avulso ao dono")
+)
+
+// Erros relacionados à Lista de Espera
+var (
+	ErrInvalidWaitlistWindow   = errors.New("janela de datas inválida: o fim deve ser posterior ao início, no futuro e em até 60 dias")
+	ErrWaitlistNotOffered      = errors.New("a entrada da lista de espera não possui oferta ativa")
+	ErrWaitlistOfferExpired    = errors.New("a oferta da lista de espera expirou")
+	ErrWaitlistEntryClosed     = errors.New("a entrada da lista de espera já foi encerrada")
+	ErrOnlyDonoManagesWaitlist = errors.New("apenas o dono pode aceitar, recusar ou cancelar sua entrada na lista de espera")
+	ErrOnlyDonoJoinsWaitlist   = errors.New("apenas o próprio dono autenticado pode entrar na lista de espera")
+	ErrFailedToCreateWaitlist  = errors.New("falha ao entrar na lista de espera")
+	ErrFailedToFetchWaitlist   = errors.New("falha ao buscar lista de espera")
+	ErrFailedToUpdateWaitlist  = errors.New("falha ao atualizar entrada da lista de espera")
 )
 
 // Erros relacionados a Agendamento
@@ -76,6 +130,7 @@ var (
 	ErrFailedToCheckAgendamento   = errors.New("falha ao verificar agendamento")
 	ErrServiceInactive            = errors.New("o serviço não está ativo")
 	ErrTotalPrevistoMismatch      = errors.New("o total previsto não corresponde à soma dos preços dos itens")
+	ErrPrecoPrevistoMismatch      = errors.New("o preço informado para o serviço não corresponde ao preço atual do petshop")
 	ErrFailedToCreateAgendamento  = errors.New("falha ao criar agendamento")
 	ErrFailedToUpdateAgendamento  = errors.New("falha ao atualizar agendamento")
 	ErrFailedToUpdateStatus       = errors.New("falha ao atualizar status do agendamento")
@@ -83,7 +138,88 @@ var (
 	ErrFailedToFetchPetInfo       = errors.New("falha ao buscar informações do pet")
 	ErrFailedToFetchDonoInfo      = errors.New("falha ao buscar informações do dono")
 	ErrFailedToFetchPetshopInfo   = errors.New("falha ao buscar informações do petshop")
-	ErrAgendamentoUpdateForbidden = errors.New("não é possível atualizar um agendamento cancelado ou concluído")
+	ErrAgendamentoUpdateForbidden = errors.New("não é possível atualizar um agendamento cancelado, concluído ou com falta registrada")
 	ErrUpdateCanceledAgendamento  = errors.New("não é possível alterar o status de um agendamento cancelado")
 	ErrUpdateCompletedAgendamento = errors.New("não é possível alterar o status de um agendamento concluído")
+	ErrHorarioIndisponivel        = errors.New("o petshop não possui disponibilidade para o horário solicitado")
+	ErrOutsideBusinessHours       = errors.New("o agendamento está fora do horário de funcionamento do petshop")
+	ErrPetshopClosed              = errors.New("o petshop estará fechado no período solicitado")
+	ErrInvalidStatusTransition    = errors.New("transição de status não permitida a partir do status atual")
+	ErrStatusTransitionForbidden  = errors.New("este tipo de usuário não pode realizar esta transição de status")
+	ErrFailedToFetchHistory       = errors.New("falha ao buscar histórico do agendamento")
+	ErrFinalPricesNotAllowed      = errors.New("preços finais só podem ser informados ao concluir o agendamento")
+	ErrItemNotFromAgendamento     = errors.New("o item informado não pertence ao agendamento")
+	ErrInvalidRecurrence          = errors.New("regra de recorrência inválida: informe data_limite ou quantidade (máximo de 52 ocorrências)")
+	ErrNoOccurrencesScheduled     = errors.New("nenhuma ocorrência da série pôde ser agendada")
+	ErrRecurrenceCanceled         = errors.New("a série de agendamentos já foi cancelada")
+	ErrFailedToCreateRecurrence   = errors.New("falha ao criar série de agendamentos")
+	ErrFailedToFetchRecurrence    = errors.New("falha ao buscar série de agendamentos")
+	ErrUpdateNoShowAgendamento    = errors.New("não é possível alterar o status de um agendamento com falta registrada")
+	ErrNoShowBeforeSchedule       = errors.New("a falta só pode ser registrada após o horário agendado")
+	ErrNoShowAfterCheckIn         = errors.New("a falta não pode ser registrada: o check-in do agendamento já foi feito")
+	ErrAwaitingPresenceConfirm    = errors.New("o dono precisa confirmar presença antes que o agendamento seja confirmado")
+	ErrOnlyDonoConfirmsPresence   = errors.New("apenas o dono pode confirmar presença no agendamento")
+	ErrFailedToFetchReliability   = errors.New("falha ao buscar histórico de faltas do dono")
+	ErrRescheduleSameDate         = errors.New("a nova data é igual à data atual do agendamento")
+	ErrFailedToReschedule         = errors.New("falha ao remarcar agendamento")
+	ErrUseRescheduleForDate       = errors.New("a data de um agendamento só pode ser alterada por POST /agendamentos/:id/remarcar")
+	ErrInvalidSortField           = errors.New("ordenação inválida: ordenar aceita data_agendada, created_at, total_previsto ou status; ordem aceita asc ou desc")
+	ErrInvalidFilterPeriod        = errors.New("período do filtro inválido: ate deve ser igual ou posterior a de")
+	ErrAgendamentoVersionConflict = errors.New("o agendamento foi alterado por outra pessoa; recarregue-o e tente novamente")
+	ErrInvalidBulkOperation       = errors.New("operação em lote inválida: informe ids (até 200) ou inicio e fim (ISO8601, até 31 dias); cancelar exige motivo e deslocar exige deslocamento_minutos diferente de zero")
+	ErrAgendamentoNotFromPetshop  = errors.New("o agendamento não pertence ao petshop informado")
+	ErrBulkOperationNotApplied    = errors.New("não aplicado: outro agendamento do lote falhou")
+	ErrFailedToApplyBulkOperation = errors.New("falha ao aplicar a operação em lote")
+	ErrInvalidAgendamentoPets     = errors.New("informe pet_id e itens ou a lista pets (até 10), com ao menos um serviço por pet e sem repetir pets")
+	ErrItemExecutionForbidden     = errors.New("apenas o petshop do agendamento pode registrar a execução dos serviços")
+	ErrItemExecutionNotAllowed    = errors.New("a execução dos serviços só pode ser registrada em agendamentos confirmados")
+	ErrPetNotInAgendamento        = errors.New("o pet informado não faz parte do agendamento")
+	ErrSkippedItemPriced          = errors.New("itens pulados não podem receber preço final")
+	ErrFailedToUpdateItem         = errors.New("falha ao atualizar item do agendamento")
+	ErrServiceAlreadyStarted      = errors.New("o atendimento já começou: os serviços só podem ser alterados pelos itens do agendamento")
+	ErrRescheduleAfterStart       = errors.New("o atendimento já começou: o agendamento não pode mais ser remarcado")
+)
+
+// Erros relacionados a tickets e check-in
+var (
+	ErrTicketForbidden      = errors.New("apenas o dono ou o petshop do agendamento podem obter o ticket")
+	ErrTicketNotAvailable   = errors.New("o ticket só é emitido para agendamentos confirmados")
+	ErrInvalidTicket        = errors.New("ticket inválido")
+	ErrTicketNotFromPetshop = errors.New("o ticket é de um agendamento de outro petshop")
+	ErrCheckInForbidden     = errors.New("apenas o petshop do agendamento pode registrar check-in e check-out")
+	ErrCheckInNotAllowed    = errors.New("o check-in só pode ser feito em agendamentos confirmados")
+	ErrCheckInOutsideWindow = errors.New("o check-in só pode ser feito no dia do agendamento")
+	ErrAlreadyCheckedIn     = errors.New("o check-in do agendamento já foi registrado")
+	ErrNotCheckedIn         = errors.New("o check-out exige o check-in do agendamento")
+	ErrCheckOutNotAllowed   = errors.New("o check-out só pode ser feito em agendamentos confirmados ou concluídos")
+	ErrAlreadyCheckedOut    = errors.New("o check-out do agendamento já foi registrado")
+	ErrFailedToCheckIn      = errors.New("falha ao registrar check-in ou check-out")
+)
+
+// Erros relacionados a chaves de idempotência
+var (
+	ErrInvalidIdempotencyKey       = errors.New("Idempotency-Key inválida: informe até 255 caracteres")
+	ErrIdempotencyKeyReused        = errors.New("a Idempotency-Key já foi usada em uma requisição diferente")
+	ErrIdempotencyKeyInProgress    = errors.New("uma requisição com esta Idempotency-Key ainda está em andamento")
+	ErrFailedToCheckIdempotencyKey = errors.New("falha ao verificar Idempotency-Key")
+)
+
+// Erros relacionados a Horário de Funcionamento
+var (
+	ErrInvalidTimeFormat           = errors.New("formato de horário inválido, use HH:MM")
+	ErrInvalidBusinessHours        = errors.New("intervalo de funcionamento inválido: o início deve ser anterior ao fim")
+	ErrOverlappingBusinessHours    = errors.New("intervalos de funcionamento sobrepostos no mesmo dia")
+	ErrFailedToFetchBusinessHours  = errors.New("falha ao buscar horário de funcionamento")
+	ErrFailedToUpdateBusinessHours = errors.New("falha ao atualizar horário de funcionamento")
+)
+
+// Erros relacionados a Fechamento de Petshop
+var (
+	ErrInvalidClosurePeriod          = errors.New("período de fechamento inválido: o início deve ser anterior ao fim")
+	ErrRecurringClosureMustBeFullDay = errors.New("fechamentos recorrentes devem ser de dia inteiro e durar menos de um ano")
+	ErrClosureNotFound               = errors.New("fechamento não encontrado")
+	ErrClosureNotFromPetshop         = errors.New("o fechamento não pertence ao petshop informado")
+	ErrFailedToCreateClosure         = errors.New("falha ao criar fechamento")
+	ErrFailedToDeleteClosure         = errors.New("falha ao excluir fechamento")
+	ErrFailedToFetchClosures         = errors.New("falha ao buscar fechamentos")
 )
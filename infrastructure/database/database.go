@@ -36,11 +36,27 @@ func SetupDatabase(config *config.Config) (*gorm.DB, error) {
 		&entities.ItemProcedimento{},
 		&entities.Agendamento{},
 		&entities.ItemAgendamento{},
+		&entities.HistoricoAgendamento{},
+		&entities.SerieAgendamento{},
+		&entities.EntradaListaEspera{},
+		&entities.ItemListaEspera{},
+		&entities.Funcionario{},
+		&entities.Recurso{},
+		&entities.ConsumoRecurso{},
+		&entities.ClienteAvulso{},
+		&entities.HorarioFuncionamento{},
+		&entities.FechamentoPetshop{},
+		&entities.ChaveIdempotencia{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("falha na migração do banco: %w", err)
 	}
 
+	// Migrações de dados de versões anteriores do esquema
+	if err := migrarDados(db); err != nil {
+		return nil, fmt.Errorf("falha na migração de dados: %w", err)
+	}
+
 	// Configurar o pool de conexões
 	sqlDB, err := db.DB()
 	if err != nil {
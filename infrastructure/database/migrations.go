@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"gorm.io/gorm"
+)
+
+// migrarDados executa as migrações de dados que o AutoMigrate não cobre.
+// Cada passo é idempotente e pode ser executado a cada inicialização
+func migrarDados(db *gorm.DB) error {
+	if err := migrarDuracaoServicos(db); err != nil {
+		return fmt.Errorf("falha ao migrar duração dos serviços: %w", err)
+	}
+	if err := preencherDataFimAgendamentos(db); err != nil {
+		return fmt.Errorf("falha ao calcular término dos agendamentos: %w", err)
+	}
+	return nil
+}
+
+// migrarDuracaoServicos converte a antiga coluna de texto livre "duracao" dos serviços
+// para "duracao_minutos" e remove a coluna antiga. Valores que não podem ser interpretados
+// mantêm a duração padrão
+func migrarDuracaoServicos(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&entities.Servico{}, "duracao") {
+		return nil
+	}
+
+	var legados []struct {
+		ID      string
+		Duracao string
+	}
+	if err := db.Table("servicos").Select("id, duracao").Where("duracao IS NOT NULL AND duracao <> ''").Scan(&legados).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, legado := range legados {
+			minutos, ok := entities.ParseDuracaoMinutos(legado.Duracao)
+			if !ok || minutos > entities.DuracaoMaximaMinutos {
+				continue
+			}
+			if err := tx.Table("servicos").Where("id = ?", legado.ID).Update("duracao_minutos", minutos).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn(&entities.Servico{}, "duracao")
+	})
+}
+
+// preencherDataFimAgendamentos calcula o término previsto dos agendamentos criados antes
+// da existência de DataFim, copiando a duração atual dos serviços para os itens
+func preencherDataFimAgendamentos(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE item_agendamentos i
+			SET duracao_minutos = s.duracao_minutos
+			FROM servicos s
+			WHERE s.id = i.servico_id AND i.duracao_minutos = 0`).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE agendamentos a
+			SET data_fim = a.data_agendada + make_interval(mins => COALESCE(NULLIF((
+				SELECT SUM(i.duracao_minutos)
+				FROM item_agendamentos i
+				WHERE i.agendamento_id = a.id AND i.deleted_at IS NULL
+			), 0), ?)::int)
+			WHERE a.data_fim IS NULL`, entities.DuracaoPadraoMinutos).Error
+	})
+}
@@ -1,12 +1,14 @@
 package repositories
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/henrygoeszanin/api_petshop/domain/entities"
 	"github.com/henrygoeszanin/api_petshop/domain/errors"
 	"github.com/segmentio/ksuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // AgendamentoRepositoryImpl implementa o repositório de Agendamento usando o GORM
@@ -28,10 +30,12 @@ func (r *AgendamentoRepositoryImpl) Create(agendamento *entities.Agendamento) er
 	return nil
 }
 
-// GetByID busca um agendamento pelo ID
+// GetByID busca um agendamento pelo ID, com os itens na ordem em que os serviços são realizados
 func (r *AgendamentoRepositoryImpl) GetByID(id ksuid.KSUID) (*entities.Agendamento, error) {
 	var agendamento entities.Agendamento
-	result := r.db.Preload("Itens").First(&agendamento, "id = ?", id)
+	result := r.db.Preload("Itens", func(db *gorm.DB) *gorm.DB {
+		return db.Order("inicio_minutos ASC")
+	}).First(&agendamento, "id = ?", id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, errors.ErrNotFound
@@ -60,33 +64,362 @@ func (r *AgendamentoRepositoryImpl) Update(agendamento *entities.Agendamento) er
 		}
 	}()
 
-	// Atualizar os itens do agendamento requer excluir os existentes e criar novos
-	if err := tx.Where("agendamento_id = ?", agendamento.ID).Delete(&entities.ItemAgendamento{}).Error; err != nil {
+	// Salvar o agendamento atualizado e seus itens, desde que ninguém o tenha alterado desde a leitura
+	if err := atualizarComVersao(tx, agendamento); err != nil {
 		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// CreateComVerificacao insere um novo agendamento após validar os conflitos de horário.
+// O registro do petshop é bloqueado (SELECT ... FOR UPDATE) durante a transação, o que
+// serializa agendamentos concorrentes do mesmo petshop e evita reservas duplicadas
+func (r *AgendamentoRepositoryImpl) CreateComVerificacao(agendamento *entities.Agendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		sobrepostos, fechamentos, err := bloquearPetshopEBuscarSobrepostos(tx, agendamento)
+		if err != nil {
+			return err
+		}
+
+		if err := verificar(sobrepostos, fechamentos); err != nil {
+			return err
+		}
+
+		if err := tx.Create(agendamento).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+		return nil
+	})
+}
+
+// CreateAvulsoComVerificacao insere o agendamento de um cliente avulso com as mesmas garantias de
+// CreateComVerificacao. Um cliente novo (sem ID) é criado na mesma transação, e nada é gravado se a verificação falhar
+func (r *AgendamentoRepositoryImpl) CreateAvulsoComVerificacao(cliente *entities.ClienteAvulso, agendamento *entities.Agendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		sobrepostos, fechamentos, err := bloquearPetshopEBuscarSobrepostos(tx, agendamento)
+		if err != nil {
+			return err
+		}
+
+		if err := verificar(sobrepostos, fechamentos); err != nil {
+			return err
+		}
+
+		if cliente.ID.IsNil() {
+			if err := tx.Create(cliente).Error; err != nil {
+				return errors.ErrInvalidData
+			}
+		}
+		agendamento.ClienteAvulsoID = &cliente.ID
+
+		if err := tx.Create(agendamento).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+		return nil
+	})
+}
+
+// UpdateComVerificacao atualiza um agendamento após validar os conflitos de horário,
+// com as mesmas garantias de concorrência de CreateComVerificacao
+func (r *AgendamentoRepositoryImpl) UpdateComVerificacao(agendamento *entities.Agendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		sobrepostos, fechamentos, err := bloquearPetshopEBuscarSobrepostos(tx, agendamento)
+		if err != nil {
+			return err
+		}
+
+		if err := verificar(sobrepostos, fechamentos); err != nil {
+			return err
+		}
+
+		return atualizarComVersao(tx, agendamento)
+	})
+}
+
+// atualizarComVersao grava todos os campos do agendamento e substitui seus itens, desde que a versão no banco
+// ainda seja a versão lida (controle de concorrência otimista). Retorna ErrAgendamentoVersionConflict quando
+// outra alteração foi gravada antes; em caso de sucesso, a versão do agendamento é incrementada
+func atualizarComVersao(tx *gorm.DB, agendamento *entities.Agendamento) error {
+	versaoLida := agendamento.Versao
+	agendamento.Versao = versaoLida + 1
+
+	result := tx.Model(agendamento).
+		Where("versao = ?", versaoLida).
+		Select("*").
+		Omit("Itens", "CreatedAt").
+		Updates(agendamento)
+	if result.Error != nil {
+		agendamento.Versao = versaoLida
 		return errors.ErrInvalidData
 	}
+	if result.RowsAffected == 0 {
+		agendamento.Versao = versaoLida
 
-	// Salvar o agendamento atualizado
-	if err := tx.Save(agendamento).Error; err != nil {
-		tx.Rollback()
+		var existentes int64
+		if err := tx.Model(&entities.Agendamento{}).Where("id = ?", agendamento.ID).Count(&existentes).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+		if existentes == 0 {
+			return errors.ErrNotFound
+		}
+		return errors.ErrAgendamentoVersionConflict
+	}
+
+	// Atualizar os itens do agendamento requer excluir os existentes e criar novos
+	if err := tx.Where("agendamento_id = ?", agendamento.ID).Delete(&entities.ItemAgendamento{}).Error; err != nil {
+		agendamento.Versao = versaoLida
 		return errors.ErrInvalidData
 	}
+	if len(agendamento.Itens) > 0 {
+		for i := range agendamento.Itens {
+			agendamento.Itens[i].AgendamentoID = agendamento.ID
+		}
+		if err := tx.Create(&agendamento.Itens).Error; err != nil {
+			agendamento.Versao = versaoLida
+			return errors.ErrInvalidData
+		}
+	}
+	return nil
+}
 
-	return tx.Commit().Error
+// SalvarItem grava a execução de um item do agendamento confirmado ou cria um item adicionado no balcão,
+// incrementando a versão do agendamento na mesma transação
+func (r *AgendamentoRepositoryImpl) SalvarItem(agendamento *entities.Agendamento, item *entities.ItemAgendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if verificar != nil {
+			sobrepostos, fechamentos, err := bloquearPetshopEBuscarSobrepostos(tx, agendamento)
+			if err != nil {
+				return err
+			}
+			if err := verificar(sobrepostos, fechamentos); err != nil {
+				return err
+			}
+		}
+
+		// Só altera se o agendamento continua confirmado e ninguém o alterou desde a leitura
+		result := tx.Model(&entities.Agendamento{}).
+			Where("id = ? AND versao = ? AND status = ?", agendamento.ID, agendamento.Versao, entities.StatusConfirmado).
+			Updates(map[string]interface{}{
+				"data_fim":       agendamento.DataFim,
+				"funcionario_id": agendamento.FuncionarioID,
+				"versao":         gorm.Expr("versao + 1"),
+			})
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrAgendamentoVersionConflict
+		}
+
+		if item.ID.IsNil() {
+			item.AgendamentoID = agendamento.ID
+			if err := tx.Create(item).Error; err != nil {
+				return errors.ErrInvalidData
+			}
+		} else {
+			result := tx.Model(item).Updates(map[string]interface{}{
+				"execucao":        item.Execucao,
+				"preco_final":     item.PrecoFinal,
+				"motivo_execucao": item.MotivoExecucao,
+			})
+			if result.Error != nil {
+				return errors.ErrInvalidData
+			}
+			if result.RowsAffected == 0 {
+				return errors.ErrNotFound
+			}
+		}
+
+		agendamento.Versao++
+		return nil
+	})
+}
+
+// Remarcar move o agendamento para a nova data após validar os conflitos de horário, com as mesmas
+// garantias de concorrência de CreateComVerificacao, e registra a remarcação no histórico
+func (r *AgendamentoRepositoryImpl) Remarcar(agendamento *entities.Agendamento, historico *entities.HistoricoAgendamento, verificar func(sobrepostos []entities.Agendamento, fechamentos []entities.FechamentoPetshop) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		sobrepostos, fechamentos, err := bloquearPetshopEBuscarSobrepostos(tx, agendamento)
+		if err != nil {
+			return err
+		}
+
+		if err := verificar(sobrepostos, fechamentos); err != nil {
+			return err
+		}
+
+		// Só remarca se ninguém alterou o agendamento (ex.: cancelou ou fez o check-in) desde a leitura
+		result := tx.Model(&entities.Agendamento{}).
+			Where("id = ? AND versao = ? AND status = ?", agendamento.ID, agendamento.Versao, historico.StatusAnterior).
+			Updates(map[string]interface{}{
+				"data_agendada":     agendamento.DataAgendada,
+				"data_fim":          agendamento.DataFim,
+				"status":            agendamento.Status,
+				"remarcacoes":       agendamento.Remarcacoes,
+				"requer_remarcacao": agendamento.RequerRemarcacao,
+				"motivo_remarcacao": agendamento.MotivoRemarcacao,
+				"funcionario_id":    agendamento.FuncionarioID,
+				"versao":            gorm.Expr("versao + 1"),
+			})
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrAgendamentoVersionConflict
+		}
+
+		if err := tx.Create(historico).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+		agendamento.Versao++
+		return nil
+	})
+}
+
+// AplicarLote grava as alterações de status e de data de vários agendamentos em uma única transação.
+// As remarcações são verificadas na ordem recebida, vendo as alterações anteriores do lote já gravadas.
+// Com parcial, cada alteração é gravada sob um savepoint e a que falha é desfeita sem afetar as demais
+func (r *AgendamentoRepositoryImpl) AplicarLote(alteracoes []entities.AlteracaoAgendamento, parcial bool) ([]error, error) {
+	falhas := make([]error, len(alteracoes))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range alteracoes {
+			if !parcial {
+				if err := aplicarAlteracao(tx, &alteracoes[i]); err != nil {
+					falhas[i] = err
+					return err
+				}
+				continue
+			}
+
+			savepoint := fmt.Sprintf("lote_%d", i)
+			if err := tx.SavePoint(savepoint).Error; err != nil {
+				return errors.ErrInvalidData
+			}
+			if err := aplicarAlteracao(tx, &alteracoes[i]); err != nil {
+				falhas[i] = err
+				if err := tx.RollbackTo(savepoint).Error; err != nil {
+					return errors.ErrInvalidData
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return falhas, err
+	}
+
+	for i := range alteracoes {
+		if falhas[i] == nil {
+			alteracoes[i].Agendamento.Versao++
+		}
+	}
+	return falhas, nil
 }
 
-// UpdateStatus atualiza apenas o status de um agendamento
-func (r *AgendamentoRepositoryImpl) UpdateStatus(id ksuid.KSUID, status entities.StatusAgendamento) error {
-	result := r.db.Model(&entities.Agendamento{}).Where("id = ?", id).Update("status", status)
+// aplicarAlteracao verifica e grava uma alteração do lote, desde que o agendamento ainda esteja na versão lida
+func aplicarAlteracao(tx *gorm.DB, alteracao *entities.AlteracaoAgendamento) error {
+	agendamento := alteracao.Agendamento
+
+	if alteracao.Verificar != nil {
+		sobrepostos, fechamentos, err := bloquearPetshopEBuscarSobrepostos(tx, agendamento)
+		if err != nil {
+			return err
+		}
+		if err := alteracao.Verificar(sobrepostos, fechamentos); err != nil {
+			return err
+		}
+	}
+
+	result := tx.Model(&entities.Agendamento{}).
+		Where("id = ? AND versao = ?", agendamento.ID, agendamento.Versao).
+		Updates(map[string]interface{}{
+			"status":                agendamento.Status,
+			"cancelado_em":          agendamento.CanceladoEm,
+			"cancelamento_no_prazo": agendamento.CancelamentoNoPrazo,
+			"taxa_cancelamento":     agendamento.TaxaCancelamento,
+			"data_agendada":         agendamento.DataAgendada,
+			"data_fim":              agendamento.DataFim,
+			"remarcacoes":           agendamento.Remarcacoes,
+			"requer_remarcacao":     agendamento.RequerRemarcacao,
+			"motivo_remarcacao":     agendamento.MotivoRemarcacao,
+			"funcionario_id":        agendamento.FuncionarioID,
+			"versao":                gorm.Expr("versao + 1"),
+		})
 	if result.Error != nil {
 		return errors.ErrInvalidData
 	}
 	if result.RowsAffected == 0 {
-		return errors.ErrNotFound
+		return errors.ErrAgendamentoVersionConflict
+	}
+
+	if err := tx.Create(alteracao.Historico).Error; err != nil {
+		return errors.ErrInvalidData
 	}
 	return nil
 }
 
+// bloquearPetshopEBuscarSobrepostos bloqueia o petshop do agendamento até o fim da transação e retorna os
+// agendamentos ativos (e reservas da lista de espera) que se sobrepõem ao período [DataAgendada, DataFim), junto
+// com os fechamentos do petshop. Tudo é lido pela própria transação, já com o petshop bloqueado
+func bloquearPetshopEBuscarSobrepostos(tx *gorm.DB, agendamento *entities.Agendamento) ([]entities.Agendamento, []entities.FechamentoPetshop, error) {
+	var petshop entities.Petshop
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&petshop, "id = ?", agendamento.PetshopID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, errors.ErrNotFound
+		}
+		return nil, nil, errors.ErrInvalidData
+	}
+
+	var fechamentos []entities.FechamentoPetshop
+	if err := tx.Where("petshop_id = ?", agendamento.PetshopID).Order("inicio ASC").Find(&fechamentos).Error; err != nil {
+		return nil, nil, errors.ErrInvalidData
+	}
+
+	query := tx.Preload("Itens").
+		Where("petshop_id = ? AND status IN ? AND data_agendada < ? AND data_fim > ?",
+			agendamento.PetshopID,
+			entities.StatusQueOcupamAgenda,
+			agendamento.DataFim, agendamento.DataAgendada)
+
+	// Na atualização o próprio agendamento não conta como conflito
+	if !agendamento.ID.IsNil() {
+		query = query.Where("id <> ?", agendamento.ID)
+	}
+
+	var sobrepostos []entities.Agendamento
+	result := query.Find(&sobrepostos)
+	if result.Error != nil {
+		return nil, nil, errors.ErrInvalidData
+	}
+
+	reservas, err := buscarReservasListaEspera(tx, agendamento.PetshopID, agendamento.DataAgendada, agendamento.DataFim)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(sobrepostos, reservas...), fechamentos, nil
+}
+
+// UpdateStatus atualiza apenas o status (e os dados de cancelamento) de um agendamento e registra
+// a mudança no histórico, criando os procedimentos gerados pela conclusão quando informados
+func (r *AgendamentoRepositoryImpl) UpdateStatus(agendamento *entities.Agendamento, historico *entities.HistoricoAgendamento, procedimentos []*entities.Procedimento) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := salvarMudancaStatus(tx, agendamento, historico); err != nil {
+			return err
+		}
+
+		for _, procedimento := range procedimentos {
+			if err := tx.Create(procedimento).Error; err != nil {
+				return errors.ErrInvalidData
+			}
+		}
+		return nil
+	})
+}
+
 // Delete exclui um agendamento do banco de dados (soft delete)
 func (r *AgendamentoRepositoryImpl) Delete(id ksuid.KSUID) error {
 	result := r.db.Delete(&entities.Agendamento{}, "id = ?", id)
@@ -99,30 +432,85 @@ func (r *AgendamentoRepositoryImpl) Delete(id ksuid.KSUID) error {
 	return nil
 }
 
-// GetByDonoID busca todos os agendamentos de um determinado dono
-func (r *AgendamentoRepositoryImpl) GetByDonoID(donoID ksuid.KSUID) ([]entities.Agendamento, error) {
+// GetByDonoID busca uma página dos agendamentos de um determinado dono que atendem ao filtro
+func (r *AgendamentoRepositoryImpl) GetByDonoID(donoID ksuid.KSUID, filtro entities.FiltroAgendamentos) ([]entities.Agendamento, int64, error) {
+	return r.listarFiltrados("dono_id", donoID, filtro)
+}
+
+// GetByPetshopID busca uma página dos agendamentos de um determinado petshop que atendem ao filtro
+func (r *AgendamentoRepositoryImpl) GetByPetshopID(petshopID ksuid.KSUID, filtro entities.FiltroAgendamentos) ([]entities.Agendamento, int64, error) {
+	return r.listarFiltrados("petshop_id", petshopID, filtro)
+}
+
+// listarFiltrados conta os agendamentos cuja coluna (dono_id ou petshop_id) é igual ao ID e que atendem
+// ao filtro, e busca a página pedida na ordenação do filtro
+func (r *AgendamentoRepositoryImpl) listarFiltrados(coluna string, id ksuid.KSUID, filtro entities.FiltroAgendamentos) ([]entities.Agendamento, int64, error) {
+	if filtro.Pagina <= 0 {
+		filtro.Pagina = 1
+	}
+	if filtro.Limite <= 0 || filtro.Limite > 100 {
+		filtro.Limite = 10
+	}
+
+	// Apenas campos conhecidos chegam ao ORDER BY
+	ordenar := "data_agendada"
+	for _, campo := range entities.CamposOrdenacaoAgendamentos {
+		if filtro.Ordenar == campo {
+			ordenar = campo
+		}
+	}
+	direcao := "DESC"
+	if filtro.Crescente {
+		direcao = "ASC"
+	}
+
+	var total int64
+	if err := filtrarAgendamentos(r.db.Model(&entities.Agendamento{}).Where(coluna+" = ?", id), filtro).
+		Count(&total).Error; err != nil {
+		return nil, 0, errors.ErrInvalidData
+	}
+
+	// O ID desempata registros com o mesmo valor, mantendo a paginação estável
 	var agendamentos []entities.Agendamento
-	result := r.db.Preload("Itens").Where("dono_id = ?", donoID).Order("data_agendada DESC").Find(&agendamentos)
+	result := filtrarAgendamentos(r.db.Preload("Itens").Where(coluna+" = ?", id), filtro).
+		Order(ordenar + " " + direcao).
+		Order("id " + direcao).
+		Offset((filtro.Pagina - 1) * filtro.Limite).
+		Limit(filtro.Limite).
+		Find(&agendamentos)
 	if result.Error != nil {
-		return nil, errors.ErrInvalidData
+		return nil, 0, errors.ErrInvalidData
 	}
-	return agendamentos, nil
+	return agendamentos, total, nil
 }
 
-// GetByPetshopID busca todos os agendamentos de um determinado petshop
-func (r *AgendamentoRepositoryImpl) GetByPetshopID(petshopID ksuid.KSUID) ([]entities.Agendamento, error) {
-	var agendamentos []entities.Agendamento
-	result := r.db.Preload("Itens").Where("petshop_id = ?", petshopID).Order("data_agendada DESC").Find(&agendamentos)
-	if result.Error != nil {
-		return nil, errors.ErrInvalidData
+// filtrarAgendamentos aplica à consulta os critérios de data, status, pet e serviço do filtro
+func filtrarAgendamentos(query *gorm.DB, filtro entities.FiltroAgendamentos) *gorm.DB {
+	if filtro.De != nil {
+		query = query.Where("data_agendada >= ?", *filtro.De)
 	}
-	return agendamentos, nil
+	if filtro.Ate != nil {
+		query = query.Where("data_agendada < ?", *filtro.Ate)
+	}
+	if len(filtro.Status) > 0 {
+		query = query.Where("status IN ?", filtro.Status)
+	}
+	if filtro.PetID != nil {
+		query = query.Where("(agendamentos.pet_id = ? OR EXISTS (SELECT 1 FROM item_agendamentos WHERE item_agendamentos.agendamento_id = agendamentos.id "+
+			"AND item_agendamentos.pet_id = ? AND item_agendamentos.deleted_at IS NULL))", *filtro.PetID, *filtro.PetID)
+	}
+	if filtro.ServicoID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM item_agendamentos WHERE item_agendamentos.agendamento_id = agendamentos.id "+
+			"AND item_agendamentos.servico_id = ? AND item_agendamentos.deleted_at IS NULL)", *filtro.ServicoID)
+	}
+	return query
 }
 
-// GetByPetID busca todos os agendamentos de um determinado pet
+// GetByPetID busca todos os agendamentos de um determinado pet, inclusive os que atendem vários pets
 func (r *AgendamentoRepositoryImpl) GetByPetID(petID ksuid.KSUID) ([]entities.Agendamento, error) {
 	var agendamentos []entities.Agendamento
-	result := r.db.Preload("Itens").Where("pet_id = ?", petID).Order("data_agendada DESC").Find(&agendamentos)
+	result := filtrarAgendamentos(r.db.Preload("Itens"), entities.FiltroAgendamentos{PetID: &petID}).
+		Order("data_agendada DESC").Find(&agendamentos)
 	if result.Error != nil {
 		return nil, errors.ErrInvalidData
 	}
@@ -143,3 +531,250 @@ func (r *AgendamentoRepositoryImpl) GetAgendamentosFuturos(petshopID ksuid.KSUID
 	}
 	return agendamentos, nil
 }
+
+// GetAgendaNoPeriodo busca os agendamentos não cancelados de um petshop que começam no período [inicio, fim),
+// em ordem de horário. Reservas da lista de espera não são incluídas
+func (r *AgendamentoRepositoryImpl) GetAgendaNoPeriodo(petshopID ksuid.KSUID, inicio, fim time.Time) ([]entities.Agendamento, error) {
+	var agendamentos []entities.Agendamento
+	result := r.db.Preload("Itens", func(db *gorm.DB) *gorm.DB {
+		return db.Order("inicio_minutos ASC")
+	}).
+		Where("petshop_id = ? AND data_agendada >= ? AND data_agendada < ? AND status != ?",
+			petshopID, inicio, fim, entities.StatusCancelado).
+		Order("data_agendada ASC").
+		Find(&agendamentos)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return agendamentos, nil
+}
+
+// GetAtivosNoPeriodo busca os agendamentos de um petshop que ocupam a agenda e se sobrepõem ao período [inicio, fim),
+// incluindo os horários reservados para ofertas da lista de espera
+func (r *AgendamentoRepositoryImpl) GetAtivosNoPeriodo(petshopID ksuid.KSUID, inicio, fim time.Time) ([]entities.Agendamento, error) {
+	var agendamentos []entities.Agendamento
+	result := r.db.Preload("Itens").
+		Where("petshop_id = ? AND status IN ? AND data_agendada < ? AND data_fim > ?",
+			petshopID,
+			entities.StatusQueOcupamAgenda,
+			fim, inicio).
+		Order("data_agendada ASC").
+		Find(&agendamentos)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+
+	reservas, err := buscarReservasListaEspera(r.db, petshopID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	return append(agendamentos, reservas...), nil
+}
+
+// GetHistorico busca as mudanças de status e remarcações de um agendamento em ordem cronológica
+func (r *AgendamentoRepositoryImpl) GetHistorico(agendamentoID ksuid.KSUID) ([]entities.HistoricoAgendamento, error) {
+	var historico []entities.HistoricoAgendamento
+	result := r.db.Where("agendamento_id = ?", agendamentoID).Order("created_at ASC").Find(&historico)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return historico, nil
+}
+
+// GetByFuncionarioNoPeriodo busca os agendamentos ativos de um funcionário que se sobrepõem ao período [inicio, fim)
+func (r *AgendamentoRepositoryImpl) GetByFuncionarioNoPeriodo(funcionarioID ksuid.KSUID, inicio, fim time.Time) ([]entities.Agendamento, error) {
+	var agendamentos []entities.Agendamento
+	result := r.db.Preload("Itens").
+		Where("funcionario_id = ? AND status IN ? AND data_agendada < ? AND data_fim > ?",
+			funcionarioID,
+			entities.StatusQueOcupamAgenda,
+			fim, inicio).
+		Order("data_agendada ASC").
+		Find(&agendamentos)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return agendamentos, nil
+}
+
+// ContarPorFuncionario conta os agendamentos ativos de cada funcionário do petshop que começam no período [inicio, fim)
+func (r *AgendamentoRepositoryImpl) ContarPorFuncionario(petshopID ksuid.KSUID, inicio, fim time.Time) (map[ksuid.KSUID]int, error) {
+	var linhas []struct {
+		FuncionarioID ksuid.KSUID
+		Total         int
+	}
+	result := r.db.Model(&entities.Agendamento{}).
+		Select("funcionario_id, COUNT(*) AS total").
+		Where("petshop_id = ? AND funcionario_id IS NOT NULL AND status IN ? AND data_agendada >= ? AND data_agendada < ?",
+			petshopID, entities.StatusQueOcupamAgenda, inicio, fim).
+		Group("funcionario_id").
+		Scan(&linhas)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+
+	contagem := make(map[ksuid.KSUID]int, len(linhas))
+	for _, linha := range linhas {
+		contagem[linha.FuncionarioID] = linha.Total
+	}
+	return contagem, nil
+}
+
+// ConfirmarPresenca registra a confirmação de presença do dono no agendamento
+func (r *AgendamentoRepositoryImpl) ConfirmarPresenca(id ksuid.KSUID, em time.Time) error {
+	result := r.db.Model(&entities.Agendamento{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"presenca_confirmada_em": em,
+		"versao":                 gorm.Expr("versao + 1"),
+	})
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// RegistrarCheckIn grava a chegada do agendamento confirmado, se ela ainda não foi registrada
+func (r *AgendamentoRepositoryImpl) RegistrarCheckIn(id ksuid.KSUID, em time.Time) error {
+	result := r.db.Model(&entities.Agendamento{}).
+		Where("id = ? AND status = ? AND check_in_em IS NULL", id, entities.StatusConfirmado).
+		Updates(map[string]interface{}{
+			"check_in_em": em,
+			"versao":      gorm.Expr("versao + 1"),
+		})
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// RegistrarCheckOut grava a saída do agendamento confirmado ou concluído com check-in, se ela ainda não foi registrada
+func (r *AgendamentoRepositoryImpl) RegistrarCheckOut(id ksuid.KSUID, em time.Time) error {
+	result := r.db.Model(&entities.Agendamento{}).
+		Where("id = ? AND status IN ? AND check_in_em IS NOT NULL AND check_out_em IS NULL", id, entities.StatusQuePermitemCheckOut).
+		Updates(map[string]interface{}{
+			"check_out_em": em,
+			"versao":       gorm.Expr("versao + 1"),
+		})
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetConfiabilidadeDonos conta os não comparecimentos de cada dono e a data do mais recente
+func (r *AgendamentoRepositoryImpl) GetConfiabilidadeDonos(donoIDs []ksuid.KSUID) (map[ksuid.KSUID]entities.ConfiabilidadeDono, error) {
+	var linhas []entities.ConfiabilidadeDono
+	result := r.db.Model(&entities.Agendamento{}).
+		Select("dono_id, COUNT(*) AS nao_comparecimentos, MAX(data_agendada) AS ultimo_nao_comparecimento").
+		Where("dono_id IN ? AND status = ?", donoIDs, entities.StatusNaoCompareceu).
+		Group("dono_id").
+		Scan(&linhas)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+
+	confiabilidades := make(map[ksuid.KSUID]entities.ConfiabilidadeDono, len(linhas))
+	for _, linha := range linhas {
+		confiabilidades[linha.DonoID] = linha
+	}
+	return confiabilidades, nil
+}
+
+// CreateSerie insere uma nova série de agendamentos recorrentes
+func (r *AgendamentoRepositoryImpl) CreateSerie(serie *entities.SerieAgendamento) error {
+	if err := r.db.Create(serie).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
+
+// GetSerieByID busca uma série de agendamentos pelo ID
+func (r *AgendamentoRepositoryImpl) GetSerieByID(id ksuid.KSUID) (*entities.SerieAgendamento, error) {
+	var serie entities.SerieAgendamento
+	result := r.db.First(&serie, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrInvalidData
+	}
+	return &serie, nil
+}
+
+// DeleteSerie remove uma série de agendamentos
+func (r *AgendamentoRepositoryImpl) DeleteSerie(id ksuid.KSUID) error {
+	result := r.db.Delete(&entities.SerieAgendamento{}, "id = ?", id)
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetBySerieID busca os agendamentos gerados por uma série, em ordem cronológica
+func (r *AgendamentoRepositoryImpl) GetBySerieID(serieID ksuid.KSUID) ([]entities.Agendamento, error) {
+	var agendamentos []entities.Agendamento
+	result := r.db.Preload("Itens").Where("serie_id = ?", serieID).Order("data_agendada ASC").Find(&agendamentos)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return agendamentos, nil
+}
+
+// CancelarSerie cancela a série e suas ocorrências informadas, registrando o histórico de cada uma.
+// Cada ocorrência só é cancelada se ainda estiver no status e na versão lidos
+func (r *AgendamentoRepositoryImpl) CancelarSerie(serieID ksuid.KSUID, agendamentos []entities.Agendamento, historicos []entities.HistoricoAgendamento) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.SerieAgendamento{}).Where("id = ?", serieID).Update("cancelada", true)
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrNotFound
+		}
+
+		for i := range agendamentos {
+			if err := salvarMudancaStatus(tx, &agendamentos[i], &historicos[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// salvarMudancaStatus grava o status e os dados de cancelamento do agendamento e o registro de histórico,
+// desde que o agendamento ainda esteja no status anterior do histórico e na versão lida. Retorna
+// ErrAgendamentoVersionConflict caso contrário, sem gravar nada
+func salvarMudancaStatus(tx *gorm.DB, agendamento *entities.Agendamento, historico *entities.HistoricoAgendamento) error {
+	result := tx.Model(&entities.Agendamento{}).
+		Where("id = ? AND status = ? AND versao = ?", agendamento.ID, historico.StatusAnterior, agendamento.Versao).
+		Updates(map[string]interface{}{
+			"status":                agendamento.Status,
+			"cancelado_em":          agendamento.CanceladoEm,
+			"cancelamento_no_prazo": agendamento.CancelamentoNoPrazo,
+			"taxa_cancelamento":     agendamento.TaxaCancelamento,
+			"versao":                gorm.Expr("versao + 1"),
+		})
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrAgendamentoVersionConflict
+	}
+	agendamento.Versao++
+
+	if err := tx.Create(historico).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// ClienteAvulsoRepositoryImpl implementa o repositório de clientes avulsos usando o GORM
+type ClienteAvulsoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewClienteAvulsoRepository cria uma nova instância do repositório de clientes avulsos
+func NewClienteAvulsoRepository(db *gorm.DB) *ClienteAvulsoRepositoryImpl {
+	return &ClienteAvulsoRepositoryImpl{db: db}
+}
+
+// Create insere um novo cliente avulso
+func (r *ClienteAvulsoRepositoryImpl) Create(cliente *entities.ClienteAvulso) error {
+	if err := r.db.Create(cliente).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
+
+// GetByID busca um cliente avulso pelo ID
+func (r *ClienteAvulsoRepositoryImpl) GetByID(id ksuid.KSUID) (*entities.ClienteAvulso, error) {
+	var cliente entities.ClienteAvulso
+	result := r.db.First(&cliente, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrInvalidData
+	}
+	return &cliente, nil
+}
+
+// BuscarNoPetshop busca o cliente não vinculado do petshop pelo contato e pelo nome do pet
+func (r *ClienteAvulsoRepositoryImpl) BuscarNoPetshop(petshopID ksuid.KSUID, email, telefone, nomePet string) (*entities.ClienteAvulso, error) {
+	if email == "" && telefone == "" {
+		return nil, errors.ErrNotFound
+	}
+
+	var cliente entities.ClienteAvulso
+	query := r.db.Where("petshop_id = ? AND dono_id IS NULL AND LOWER(nome_pet) = ?", petshopID, strings.ToLower(nomePet))
+	result := filtroContato(query, email, telefone).Order("created_at ASC").First(&cliente)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrInvalidData
+	}
+	return &cliente, nil
+}
+
+// VincularAoDono vincula o cliente avulso ao dono em uma única transação. O pet do cliente reaproveita o pet
+// criado por outro vínculo do mesmo dono com o mesmo nome ou vira um novo pet do dono, e os agendamentos do
+// cliente passam a ser do dono e do pet
+func (r *ClienteAvulsoRepositoryImpl) VincularAoDono(cliente *entities.ClienteAvulso, dono *entities.Dono) (int, error) {
+	var vinculados int64
+	agora := time.Now()
+	var petID ksuid.KSUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// O mesmo pet atendido em mais de um petshop vira um único pet do dono
+		var anterior entities.ClienteAvulso
+		result := tx.Where("dono_id = ? AND pet_id IS NOT NULL AND LOWER(nome_pet) = ?", dono.ID, strings.ToLower(cliente.NomePet)).
+			Order("vinculado_em ASC").Limit(1).Find(&anterior)
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		if result.RowsAffected > 0 {
+			petID = *anterior.PetID
+		} else {
+			pet := &entities.Pet{
+				Nome:    cliente.NomePet,
+				Especie: cliente.EspeciePet,
+				Raca:    cliente.RacaPet,
+				DonoID:  dono.ID,
+			}
+			if err := tx.Create(pet).Error; err != nil {
+				return errors.ErrInvalidData
+			}
+			petID = pet.ID
+		}
+
+		// Só vincula se nenhum outro vínculo foi gravado desde a leitura do cliente
+		result = tx.Model(&entities.ClienteAvulso{}).Where("id = ? AND dono_id IS NULL", cliente.ID).
+			Updates(map[string]interface{}{"dono_id": dono.ID, "pet_id": petID, "vinculado_em": agora})
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrGuestAlreadyLinked
+		}
+
+		result = tx.Model(&entities.Agendamento{}).Where("cliente_avulso_id = ? AND dono_id IS NULL", cliente.ID).
+			Updates(map[string]interface{}{"dono_id": dono.ID, "pet_id": petID, "versao": gorm.Expr("versao + 1")})
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		vinculados = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	cliente.DonoID = &dono.ID
+	cliente.PetID = &petID
+	cliente.VinculadoEm = &agora
+	return int(vinculados), nil
+}
+
+// filtroContato restringe a consulta aos clientes com o email ou o telefone informados, ignorando os vazios
+func filtroContato(query *gorm.DB, email, telefone string) *gorm.DB {
+	switch {
+	case email != "" && telefone != "":
+		return query.Where("email = ? OR telefone = ?", email, telefone)
+	case email != "":
+		return query.Where("email = ?", email)
+	default:
+		return query.Where("telefone = ?", telefone)
+	}
+}
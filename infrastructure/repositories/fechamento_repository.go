@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FechamentoRepositoryImpl implementa o repositório de FechamentoPetshop usando o GORM
+type FechamentoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFechamentoRepository cria uma nova instância do repositório de FechamentoPetshop
+func NewFechamentoRepository(db *gorm.DB) *FechamentoRepositoryImpl {
+	return &FechamentoRepositoryImpl{db: db}
+}
+
+// Create insere um novo fechamento e sinaliza os agendamentos afetados.
+// O petshop é bloqueado durante a transação, assim como na criação de agendamentos, que verificam os
+// fechamentos com esse bloqueio: cada reserva concorrente ou vê o fechamento e é recusada, ou é gravada
+// antes dele e recebe a sinalização
+func (r *FechamentoRepositoryImpl) Create(fechamento *entities.FechamentoPetshop, ocorrencias []entities.Periodo) ([]ksuid.KSUID, error) {
+	var afetados []ksuid.KSUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var petshop entities.Petshop
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&petshop, "id = ?", fechamento.PetshopID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrNotFound
+			}
+			return errors.ErrInvalidData
+		}
+
+		if err := tx.Create(fechamento).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+
+		motivo := "Petshop fechado"
+		if fechamento.Motivo != "" {
+			motivo += ": " + fechamento.Motivo
+		}
+
+		for _, ocorrencia := range ocorrencias {
+			var atingidos []entities.Agendamento
+			result := tx.Model(&atingidos).
+				Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+				Where("petshop_id = ? AND status IN ? AND data_agendada < ? AND data_fim > ?",
+					fechamento.PetshopID, entities.StatusQueOcupamAgenda, ocorrencia.Fim, ocorrencia.Inicio).
+				Updates(map[string]interface{}{
+					"requer_remarcacao": true,
+					"motivo_remarcacao": motivo,
+					"versao":            gorm.Expr("versao + 1"),
+				})
+			if result.Error != nil {
+				return errors.ErrInvalidData
+			}
+			for _, atingido := range atingidos {
+				afetados = append(afetados, atingido.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return afetados, nil
+}
+
+// GetByID busca um fechamento pelo ID
+func (r *FechamentoRepositoryImpl) GetByID(id ksuid.KSUID) (*entities.FechamentoPetshop, error) {
+	var fechamento entities.FechamentoPetshop
+	result := r.db.First(&fechamento, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrInvalidData
+	}
+	return &fechamento, nil
+}
+
+// Delete exclui um fechamento do banco de dados (soft delete)
+func (r *FechamentoRepositoryImpl) Delete(id ksuid.KSUID) error {
+	result := r.db.Delete(&entities.FechamentoPetshop{}, "id = ?", id)
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetByPetshopID busca todos os fechamentos de um petshop
+func (r *FechamentoRepositoryImpl) GetByPetshopID(petshopID ksuid.KSUID) ([]entities.FechamentoPetshop, error) {
+	var fechamentos []entities.FechamentoPetshop
+	result := r.db.Where("petshop_id = ?", petshopID).Order("inicio ASC").Find(&fechamentos)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return fechamentos, nil
+}
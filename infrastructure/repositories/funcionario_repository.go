@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// FuncionarioRepositoryImpl implementa o repositório de Funcionario usando o GORM
+type FuncionarioRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFuncionarioRepository cria uma nova instância do repositório de Funcionario
+func NewFuncionarioRepository(db *gorm.DB) *FuncionarioRepositoryImpl {
+	return &FuncionarioRepositoryImpl{db: db}
+}
+
+// Create insere um novo funcionário com os serviços que ele realiza.
+// Os serviços já existem: apenas os vínculos são gravados, sem recriar os registros de serviço
+func (r *FuncionarioRepositoryImpl) Create(funcionario *entities.Funcionario) error {
+	if err := r.db.Omit("Servicos.*").Create(funcionario).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
+
+// GetByID busca um funcionário pelo ID
+func (r *FuncionarioRepositoryImpl) GetByID(id ksuid.KSUID) (*entities.Funcionario, error) {
+	var funcionario entities.Funcionario
+	result := r.db.Preload("Servicos").First(&funcionario, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrInvalidData
+	}
+	return &funcionario, nil
+}
+
+// Update atualiza os dados de um funcionário e substitui os serviços que ele realiza
+func (r *FuncionarioRepositoryImpl) Update(funcionario *entities.Funcionario) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(funcionario).Select("nome", "ativo").Updates(funcionario)
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrNotFound
+		}
+
+		if err := tx.Model(funcionario).Omit("Servicos.*").Association("Servicos").Replace(funcionario.Servicos); err != nil {
+			return errors.ErrInvalidData
+		}
+		return nil
+	})
+}
+
+// Delete exclui um funcionário do banco de dados (soft delete)
+func (r *FuncionarioRepositoryImpl) Delete(id ksuid.KSUID) error {
+	result := r.db.Delete(&entities.Funcionario{}, "id = ?", id)
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetByPetshopID busca todos os funcionários de um petshop, em ordem alfabética
+func (r *FuncionarioRepositoryImpl) GetByPetshopID(petshopID ksuid.KSUID) ([]entities.Funcionario, error) {
+	var funcionarios []entities.Funcionario
+	result := r.db.Preload("Servicos").Where("petshop_id = ?", petshopID).Order("nome ASC").Find(&funcionarios)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return funcionarios, nil
+}
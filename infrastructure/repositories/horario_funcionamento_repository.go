@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// HorarioFuncionamentoRepositoryImpl implementa o repositório de HorarioFuncionamento usando o GORM
+type HorarioFuncionamentoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewHorarioFuncionamentoRepository cria uma nova instância do repositório de HorarioFuncionamento
+func NewHorarioFuncionamentoRepository(db *gorm.DB) *HorarioFuncionamentoRepositoryImpl {
+	return &HorarioFuncionamentoRepositoryImpl{db: db}
+}
+
+// GetByPetshopID busca todos os intervalos de funcionamento de um petshop
+func (r *HorarioFuncionamentoRepositoryImpl) GetByPetshopID(petshopID ksuid.KSUID) ([]entities.HorarioFuncionamento, error) {
+	var horarios []entities.HorarioFuncionamento
+	result := r.db.Where("petshop_id = ?", petshopID).Order("dia_semana ASC, inicio_minutos ASC").Find(&horarios)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return horarios, nil
+}
+
+// ReplaceByPetshopID remove os intervalos atuais do petshop e insere os novos na mesma transação
+func (r *HorarioFuncionamentoRepositoryImpl) ReplaceByPetshopID(petshopID ksuid.KSUID, horarios []entities.HorarioFuncionamento) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("petshop_id = ?", petshopID).Delete(&entities.HorarioFuncionamento{}).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+
+		if len(horarios) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&horarios).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+		return nil
+	})
+}
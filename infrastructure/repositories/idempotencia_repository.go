@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotenciaRepositoryImpl implementa o repositório de chaves de idempotência usando o GORM
+type IdempotenciaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewIdempotenciaRepository cria uma nova instância do repositório de chaves de idempotência
+func NewIdempotenciaRepository(db *gorm.DB) *IdempotenciaRepositoryImpl {
+	return &IdempotenciaRepositoryImpl{db: db}
+}
+
+// Reservar grava a chave para o usuário, reaproveitando chaves expiradas. A gravação usa ON CONFLICT
+// para que requisições simultâneas com a mesma chave não passem ambas pela reserva
+func (r *IdempotenciaRepositoryImpl) Reservar(chave *entities.ChaveIdempotencia) (*entities.ChaveIdempotencia, error) {
+	var existente *entities.ChaveIdempotencia
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("usuario_id = ? AND chave = ? AND expira_em <= ?", chave.UsuarioID, chave.Chave, time.Now()).
+			Delete(&entities.ChaveIdempotencia{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(chave)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil
+		}
+
+		existente = &entities.ChaveIdempotencia{}
+		return tx.First(existente, "usuario_id = ? AND chave = ?", chave.UsuarioID, chave.Chave).Error
+	})
+	if err != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return existente, nil
+}
+
+// Concluir guarda o status e o corpo da resposta da requisição original
+func (r *IdempotenciaRepositoryImpl) Concluir(id ksuid.KSUID, statusCode int, resposta []byte) error {
+	result := r.db.Model(&entities.ChaveIdempotencia{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status_code": statusCode, "resposta": resposta})
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Liberar remove a chave reservada
+func (r *IdempotenciaRepositoryImpl) Liberar(id ksuid.KSUID) error {
+	if err := r.db.Delete(&entities.ChaveIdempotencia{}, "id = ?", id).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
+
+// RemoverExpiradas remove as chaves cuja janela terminou até o instante informado
+func (r *IdempotenciaRepositoryImpl) RemoverExpiradas(ate time.Time) error {
+	if err := r.db.Where("expira_em <= ?", ate).Delete(&entities.ChaveIdempotencia{}).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
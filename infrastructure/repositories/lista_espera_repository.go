@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// ListaEsperaRepositoryImpl implementa o repositório da lista de espera usando o GORM
+type ListaEsperaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewListaEsperaRepository cria uma nova instância do repositório da lista de espera
+func NewListaEsperaRepository(db *gorm.DB) *ListaEsperaRepositoryImpl {
+	return &ListaEsperaRepositoryImpl{db: db}
+}
+
+// Create insere uma nova entrada na lista de espera com seus itens
+func (r *ListaEsperaRepositoryImpl) Create(entrada *entities.EntradaListaEspera) error {
+	if err := r.db.Create(entrada).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
+
+// GetByID busca uma entrada da lista de espera pelo ID
+func (r *ListaEsperaRepositoryImpl) GetByID(id ksuid.KSUID) (*entities.EntradaListaEspera, error) {
+	var entrada entities.EntradaListaEspera
+	result := r.db.Preload("Itens").First(&entrada, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrInvalidData
+	}
+	return &entrada, nil
+}
+
+// GetByDonoID busca todas as entradas de um determinado dono
+func (r *ListaEsperaRepositoryImpl) GetByDonoID(donoID ksuid.KSUID) ([]entities.EntradaListaEspera, error) {
+	var entradas []entities.EntradaListaEspera
+	result := r.db.Preload("Itens").Where("dono_id = ?", donoID).Order("created_at DESC").Find(&entradas)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return entradas, nil
+}
+
+// GetByPetshopID busca todas as entradas de um determinado petshop, por ordem de chegada
+func (r *ListaEsperaRepositoryImpl) GetByPetshopID(petshopID ksuid.KSUID) ([]entities.EntradaListaEspera, error) {
+	var entradas []entities.EntradaListaEspera
+	result := r.db.Preload("Itens").Where("petshop_id = ?", petshopID).Order("created_at ASC").Find(&entradas)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return entradas, nil
+}
+
+// GetAguardandoParaHorario busca as entradas aguardando que aceitam um atendimento iniciando no horário informado
+func (r *ListaEsperaRepositoryImpl) GetAguardandoParaHorario(petshopID ksuid.KSUID, inicio time.Time) ([]entities.EntradaListaEspera, error) {
+	var entradas []entities.EntradaListaEspera
+	result := r.db.Preload("Itens").
+		Where("petshop_id = ? AND status = ? AND janela_inicio <= ? AND janela_fim > ?",
+			petshopID, entities.StatusEsperaAguardando, inicio, inicio).
+		Order("created_at ASC").
+		Find(&entradas)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return entradas, nil
+}
+
+// GetOfertasExpiradas busca as entradas ofertadas cujo prazo de reserva terminou
+func (r *ListaEsperaRepositoryImpl) GetOfertasExpiradas(agora time.Time) ([]entities.EntradaListaEspera, error) {
+	var entradas []entities.EntradaListaEspera
+	result := r.db.Where("status = ? AND oferta_expira_em <= ?", entities.StatusEsperaOfertada, agora).Find(&entradas)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return entradas, nil
+}
+
+// Ofertar grava o horário ofertado e o prazo da reserva de uma entrada que ainda está aguardando
+func (r *ListaEsperaRepositoryImpl) Ofertar(entrada *entities.EntradaListaEspera) error {
+	result := r.db.Model(&entities.EntradaListaEspera{}).
+		Where("id = ? AND status = ?", entrada.ID, entities.StatusEsperaAguardando).
+		Updates(map[string]interface{}{
+			"status":           entities.StatusEsperaOfertada,
+			"oferta_inicio":    entrada.OfertaInicio,
+			"oferta_fim":       entrada.OfertaFim,
+			"oferta_expira_em": entrada.OfertaExpiraEm,
+		})
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	entrada.Status = entities.StatusEsperaOfertada
+	return nil
+}
+
+// AtualizarStatus muda o status de uma entrada que está no status esperado
+func (r *ListaEsperaRepositoryImpl) AtualizarStatus(id ksuid.KSUID, de, para entities.StatusListaEspera, agendamentoID *ksuid.KSUID) error {
+	result := r.db.Model(&entities.EntradaListaEspera{}).
+		Where("id = ? AND status = ?", id, de).
+		Updates(map[string]interface{}{
+			"status":         para,
+			"agendamento_id": agendamentoID,
+		})
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ExpirarJanelasVencidas encerra as entradas aguardando cuja janela de datas já terminou
+func (r *ListaEsperaRepositoryImpl) ExpirarJanelasVencidas(agora time.Time) error {
+	result := r.db.Model(&entities.EntradaListaEspera{}).
+		Where("status = ? AND janela_fim <= ?", entities.StatusEsperaAguardando, agora).
+		Update("status", entities.StatusEsperaExpirada)
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
+
+// buscarReservasListaEspera retorna os horários ofertados pela lista de espera que ainda estão
+// reservados e se sobrepõem ao período [inicio, fim), representados como agendamentos
+func buscarReservasListaEspera(tx *gorm.DB, petshopID ksuid.KSUID, inicio, fim time.Time) ([]entities.Agendamento, error) {
+	var entradas []entities.EntradaListaEspera
+	result := tx.Preload("Itens").Where("petshop_id = ? AND status = ? AND oferta_expira_em > ? AND oferta_inicio < ? AND oferta_fim > ?",
+		petshopID, entities.StatusEsperaOfertada, time.Now(), fim, inicio).
+		Find(&entradas)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+
+	reservas := make([]entities.Agendamento, 0, len(entradas))
+	for i := range entradas {
+		reservas = append(reservas, entradas[i].Reserva())
+	}
+	return reservas, nil
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+	"gorm.io/gorm"
+)
+
+// RecursoRepositoryImpl implementa o repositório de Recurso usando o GORM
+type RecursoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRecursoRepository cria uma nova instância do repositório de Recurso
+func NewRecursoRepository(db *gorm.DB) *RecursoRepositoryImpl {
+	return &RecursoRepositoryImpl{db: db}
+}
+
+// Create insere um novo recurso no banco de dados
+func (r *RecursoRepositoryImpl) Create(recurso *entities.Recurso) error {
+	if err := r.db.Omit("Consumos").Create(recurso).Error; err != nil {
+		return errors.ErrInvalidData
+	}
+	return nil
+}
+
+// GetByID busca um recurso pelo ID
+func (r *RecursoRepositoryImpl) GetByID(id ksuid.KSUID) (*entities.Recurso, error) {
+	var recurso entities.Recurso
+	result := r.db.Preload("Consumos").First(&recurso, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.ErrInvalidData
+	}
+	return &recurso, nil
+}
+
+// Update atualiza o nome e a quantidade de um recurso
+func (r *RecursoRepositoryImpl) Update(recurso *entities.Recurso) error {
+	result := r.db.Model(recurso).Select("nome", "quantidade").Updates(recurso)
+	if result.Error != nil {
+		return errors.ErrInvalidData
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Delete exclui um recurso (soft delete) e remove os consumos associados a ele
+func (r *RecursoRepositoryImpl) Delete(id ksuid.KSUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entities.Recurso{}, "id = ?", id)
+		if result.Error != nil {
+			return errors.ErrInvalidData
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrNotFound
+		}
+
+		if err := tx.Where("recurso_id = ?", id).Delete(&entities.ConsumoRecurso{}).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+		return nil
+	})
+}
+
+// GetByPetshopID busca todos os recursos de um petshop, em ordem alfabética, com seus consumos
+func (r *RecursoRepositoryImpl) GetByPetshopID(petshopID ksuid.KSUID) ([]entities.Recurso, error) {
+	var recursos []entities.Recurso
+	result := r.db.Preload("Consumos").Where("petshop_id = ?", petshopID).Order("nome ASC").Find(&recursos)
+	if result.Error != nil {
+		return nil, errors.ErrInvalidData
+	}
+	return recursos, nil
+}
+
+// SubstituirConsumosDoServico exclui os consumos de recursos do serviço e grava os novos na mesma transação
+func (r *RecursoRepositoryImpl) SubstituirConsumosDoServico(servicoID ksuid.KSUID, consumos []entities.ConsumoRecurso) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("servico_id = ?", servicoID).Delete(&entities.ConsumoRecurso{}).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+
+		if len(consumos) == 0 {
+			return nil
+		}
+		if err := tx.Create(&consumos).Error; err != nil {
+			return errors.ErrInvalidData
+		}
+		return nil
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
+	_ "time/tzdata" // Garante a base de fusos horários mesmo em imagens sem tzdata
 
 	"github.com/gin-gonic/gin"
 	"github.com/henrygoeszanin/api_petshop/application/services"
@@ -44,6 +46,14 @@ func main() {
 	petRepo := repositories.NewPetRepository(db)
 	servicoRepo := repositories.NewServicoRepository(db)
 	agendamentoRepo := repositories.NewAgendamentoRepository(db)
+	horarioRepo := repositories.NewHorarioFuncionamentoRepository(db)
+	fechamentoRepo := repositories.NewFechamentoRepository(db)
+	procedimentoRepo := repositories.NewProcedimentoRepository(db)
+	listaEsperaRepo := repositories.NewListaEsperaRepository(db)
+	funcionarioRepo := repositories.NewFuncionarioRepository(db)
+	recursoRepo := repositories.NewRecursoRepository(db)
+	idempotenciaRepo := repositories.NewIdempotenciaRepository(db)
+	clienteAvulsoRepo := repositories.NewClienteAvulsoRepository(db)
 
 	// Inicializa os serviços
 	authService := services.NewAuthService(donoRepo, petshopRepo)
@@ -51,7 +61,13 @@ func main() {
 	donoService := services.NewDonoService(donoRepo)
 	petService := services.NewPetService(petRepo, donoRepo)
 	servicoService := services.NewServicoService(servicoRepo, petshopRepo)
-	agendamentoService := services.NewAgendamentoService(agendamentoRepo, donoRepo, petRepo, petshopRepo, servicoRepo)
+	agendamentoService := services.NewAgendamentoService(agendamentoRepo, donoRepo, petRepo, petshopRepo, servicoRepo, horarioRepo, fechamentoRepo, listaEsperaRepo, funcionarioRepo, recursoRepo, clienteAvulsoRepo, cfg.TicketSecret)
+	horarioService := services.NewHorarioFuncionamentoService(horarioRepo, petshopRepo)
+	fechamentoService := services.NewFechamentoService(fechamentoRepo, petshopRepo)
+	procedimentoService := services.NewProcedimentoService(procedimentoRepo, petRepo, petshopRepo, servicoRepo)
+	funcionarioService := services.NewFuncionarioService(funcionarioRepo, petshopRepo, servicoRepo)
+	recursoService := services.NewRecursoService(recursoRepo, petshopRepo, servicoRepo)
+	idempotenciaService := services.NewIdempotenciaService(idempotenciaRepo, cfg.IdempotencyWindow)
 
 	// Configura os middlewares
 	authMiddleware, err := middlewares.SetupJWTMiddleware(authService, cfg)
@@ -62,6 +78,7 @@ func main() {
 	middlewares.SetServicoService(servicoService)
 	middlewares.SetPetService(petService)
 	middlewares.SetAgendamentoService(agendamentoService)
+	middlewares.SetIdempotenciaService(idempotenciaService)
 
 	// Inicializa os handlers
 	authHandler := handlers.NewAuthHandler(authService, authMiddleware)
@@ -71,6 +88,12 @@ func main() {
 	profileHandler := handlers.NewProfileHandler(donoService, petshopService)
 	servicoHandler := handlers.NewServicoHandler(servicoService)
 	agendamentoHandler := handlers.NewAgendamentoHandler(agendamentoService)
+	horarioHandler := handlers.NewHorarioFuncionamentoHandler(horarioService)
+	fechamentoHandler := handlers.NewFechamentoHandler(fechamentoService)
+	procedimentoHandler := handlers.NewProcedimentoHandler(procedimentoService)
+	listaEsperaHandler := handlers.NewListaEsperaHandler(agendamentoService)
+	funcionarioHandler := handlers.NewFuncionarioHandler(funcionarioService)
+	recursoHandler := handlers.NewRecursoHandler(recursoService)
 
 	// Configura as rotas
 	routes.SetupAuthRoutes(router, authHandler, authMiddleware)
@@ -80,6 +103,18 @@ func main() {
 	routes.SetupProfileRoutes(router, profileHandler, authMiddleware)
 	routes.SetupServicoRoutes(router, servicoHandler, authMiddleware)
 	routes.SetupAgendamentoRoutes(router, agendamentoHandler, authMiddleware)
+	routes.SetupHorarioFuncionamentoRoutes(router, horarioHandler, authMiddleware)
+	routes.SetupFechamentoRoutes(router, fechamentoHandler, authMiddleware)
+	routes.SetupProcedimentoRoutes(router, procedimentoHandler, authMiddleware)
+	routes.SetupListaEsperaRoutes(router, listaEsperaHandler, authMiddleware)
+	routes.SetupFuncionarioRoutes(router, funcionarioHandler, authMiddleware)
+	routes.SetupRecursoRoutes(router, recursoHandler, authMiddleware)
+
+	// Expira as ofertas da lista de espera não respondidas e repassa os horários
+	agendamentoService.IniciarProcessamentoListaEspera(time.Minute)
+
+	// Remove as chaves de idempotência cuja janela terminou
+	idempotenciaService.IniciarLimpeza(time.Hour)
 
 	// Inicia o servidor
 	serverAddr := fmt.Sprintf(":%s", cfg.ServerPort)
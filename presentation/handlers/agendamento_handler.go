@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/henrygoeszanin/api_petshop/application/dtos"
@@ -33,13 +35,91 @@ func (h *AgendamentoHandler) Create(c *gin.Context) {
 
 	response, err := h.agendamentoService.Create(&dto)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao criar agendamento: %v", err)})
+		switch err {
+		case errors.ErrHorarioIndisponivel, errors.ErrPetshopClosed, errors.ErrRecursoIndisponivel,
+			errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable:
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Erro ao criar agendamento: %v", err)})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao criar agendamento: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// CreatePeloPetshop processa a requisição do petshop para agendar por telefone ou no balcão,
+// para um dono cadastrado ou para um cliente sem conta
+func (h *AgendamentoHandler) CreatePeloPetshop(c *gin.Context) {
+	petshopID, err := ksuid.Parse(c.Param("petshopId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	var dto dtos.AgendamentoPetshopCreateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.agendamentoService.CreatePeloPetshop(petshopID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrHorarioIndisponivel, errors.ErrPetshopClosed, errors.ErrRecursoIndisponivel,
+			errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable, errors.ErrClienteAvulsoJaCadastrado:
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Erro ao criar agendamento: %v", err)})
+		case errors.ErrFailedToFetchGuest:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao criar agendamento: %v", err)})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao criar agendamento: %v", err)})
+		}
 		return
 	}
 
 	c.JSON(http.StatusCreated, response)
 }
 
+// VincularClienteAvulso processa a confirmação, pelo petshop, de que um cliente avulso é um dono cadastrado
+func (h *AgendamentoHandler) VincularClienteAvulso(c *gin.Context) {
+	petshopID, err := ksuid.Parse(c.Param("petshopId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	clienteID, err := ksuid.Parse(c.Param("clienteId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cliente avulso inválido"})
+		return
+	}
+
+	var dto dtos.ClienteAvulsoVincularDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vinculo, err := h.agendamentoService.VincularClienteAvulso(petshopID, clienteID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cliente avulso não encontrado"})
+		case errors.ErrDonoNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.ErrGuestAlreadyLinked, errors.ErrGuestContactMismatch:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.ErrInvalidID:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao vincular cliente avulso: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, vinculo)
+}
+
 // GetByID processa a requisição para buscar um agendamento por ID
 func (h *AgendamentoHandler) GetByID(c *gin.Context) {
 	// Extrair o ID da requisição
@@ -62,43 +142,77 @@ func (h *AgendamentoHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	// A versão permite ao cliente atualizar com If-Match sem sobrescrever alterações de outra pessoa
+	c.Header("ETag", etagVersao(agendamento.Versao))
 	c.JSON(http.StatusOK, agendamento)
 }
 
-// GetByDonoID processa a requisição para listar todos os agendamentos de um dono
+// GetByDonoID processa a requisição para listar os agendamentos de um dono, com filtros,
+// ordenação e paginação recebidos pela query string
 func (h *AgendamentoHandler) GetByDonoID(c *gin.Context) {
 	// Extrair o ID do dono da requisição
-	donoIDStr := c.Param("donoId")
+	donoIDStr := c.Param("id")
 	donoID, err := ksuid.Parse(donoIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do dono inválido"})
 		return
 	}
 
+	// Extrair filtros da query
+	var filtro dtos.AgendamentoFiltroDTO
+	if err := c.ShouldBindQuery(&filtro); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Buscar agendamentos do dono
-	agendamentos, err := h.agendamentoService.GetByDonoID(donoID)
+	agendamentos, err := h.agendamentoService.GetByDonoID(donoID, &filtro)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar agendamentos: %v", err)})
+		switch err {
+		case errors.ErrDonoNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Dono não encontrado"})
+		case errors.ErrInvalidDate, errors.ErrInvalidFilterPeriod, errors.ErrInvalidAgendamentoStatus,
+			errors.ErrInvalidID, errors.ErrInvalidSortField:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar agendamentos: %v", err)})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, agendamentos)
 }
 
-// GetByPetshopID processa a requisição para listar todos os agendamentos de um petshop
+// GetByPetshopID processa a requisição para listar os agendamentos de um petshop, com filtros,
+// ordenação e paginação recebidos pela query string
 func (h *AgendamentoHandler) GetByPetshopID(c *gin.Context) {
 	// Extrair o ID do petshop da requisição
-	petshopIDStr := c.Param("petshopId")
+	petshopIDStr := c.Param("id")
 	petshopID, err := ksuid.Parse(petshopIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
 		return
 	}
 
+	// Extrair filtros da query
+	var filtro dtos.AgendamentoFiltroDTO
+	if err := c.ShouldBindQuery(&filtro); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Buscar agendamentos do petshop
-	agendamentos, err := h.agendamentoService.GetByPetshopID(petshopID)
+	agendamentos, err := h.agendamentoService.GetByPetshopID(petshopID, &filtro)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar agendamentos: %v", err)})
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidDate, errors.ErrInvalidFilterPeriod, errors.ErrInvalidAgendamentoStatus,
+			errors.ErrInvalidID, errors.ErrInvalidSortField:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar agendamentos: %v", err)})
+		}
 		return
 	}
 
@@ -122,12 +236,26 @@ func (h *AgendamentoHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
+	// Identificar quem está alterando o status
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
 	// Atualizar status do agendamento
-	agendamento, err := h.agendamentoService.UpdateStatus(id, &dto)
+	agendamento, err := h.agendamentoService.UpdateStatus(id, &dto, ator)
 	if err != nil {
 		switch err {
 		case errors.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+		case errors.ErrStatusTransitionForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.ErrInvalidStatusTransition, errors.ErrUpdateCanceledAgendamento, errors.ErrUpdateCompletedAgendamento,
+			errors.ErrUpdateNoShowAgendamento, errors.ErrNoShowBeforeSchedule, errors.ErrNoShowAfterCheckIn, errors.ErrAwaitingPresenceConfirm:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.ErrAgendamentoVersionConflict:
+			h.responderConflitoVersao(c, id, err)
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao atualizar status do agendamento: %v", err)})
 		}
@@ -154,17 +282,576 @@ func (h *AgendamentoHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// Versão editada pelo cliente (opcional), recebida do ETag de GET /agendamentos/:id
+	versaoEsperada, ok := versaoIfMatch(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cabeçalho If-Match inválido: use o ETag retornado pelo agendamento"})
+		return
+	}
+
 	// Atualizar agendamento
-	agendamento, err := h.agendamentoService.Update(id, &dto)
+	agendamento, err := h.agendamentoService.Update(id, &dto, versaoEsperada)
 	if err != nil {
 		switch err {
 		case errors.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+		case errors.ErrAgendamentoVersionConflict:
+			h.responderConflitoVersao(c, id, err)
+		case errors.ErrHorarioIndisponivel, errors.ErrPetshopClosed, errors.ErrRecursoIndisponivel,
+			errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable, errors.ErrUseRescheduleForDate, errors.ErrServiceAlreadyStarted:
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Erro ao atualizar agendamento: %v", err)})
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao atualizar agendamento: %v", err)})
 		}
 		return
 	}
 
+	c.Header("ETag", etagVersao(agendamento.Versao))
+	c.JSON(http.StatusOK, agendamento)
+}
+
+// GetDisponibilidade processa a consulta de horários disponíveis de um petshop em uma data
+func (h *AgendamentoHandler) GetDisponibilidade(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	// Extrair parâmetros da query
+	data := c.Query("data")
+	if data == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'data' é obrigatório (formato AAAA-MM-DD)"})
+		return
+	}
+
+	servicosParam := c.Query("servicos")
+	if servicosParam == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'servicos' é obrigatório (IDs separados por vírgula)"})
+		return
+	}
+	servicoIDs := strings.Split(servicosParam, ",")
+
+	disponibilidade, err := h.agendamentoService.GetDisponibilidade(petshopID, data, servicoIDs)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound, errors.ErrServiceNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.ErrFailedToCheckPetshop, errors.ErrFailedToCheckService, errors.ErrFailedToFetchBusinessHours,
+			errors.ErrFailedToFetchAgendamentos, errors.ErrFailedToFetchClosures, errors.ErrFailedToFetchStaff, errors.ErrFailedToFetchResources:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao consultar disponibilidade: %v", err)})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao consultar disponibilidade: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, disponibilidade)
+}
+
+// Remarcar processa a mudança de data de um agendamento, mantendo serviços e preços
+func (h *AgendamentoHandler) Remarcar(c *gin.Context) {
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var dto dtos.AgendamentoRemarcarDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Identificar quem está remarcando
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	// Versão remarcada pelo cliente (opcional), recebida do ETag de GET /agendamentos/:id
+	versaoEsperada, ok := versaoIfMatch(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cabeçalho If-Match inválido: use o ETag retornado pelo agendamento"})
+		return
+	}
+
+	agendamento, err := h.agendamentoService.Remarcar(id, &dto, ator, versaoEsperada)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+		case errors.ErrAgendamentoVersionConflict:
+			h.responderConflitoVersao(c, id, err)
+		case errors.ErrHorarioIndisponivel, errors.ErrPetshopClosed, errors.ErrAgendamentoUpdateForbidden,
+			errors.ErrRecursoIndisponivel, errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable, errors.ErrRescheduleAfterStart:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao remarcar agendamento: %v", err)})
+		}
+		return
+	}
+
+	c.Header("ETag", etagVersao(agendamento.Versao))
+	c.JSON(http.StatusOK, agendamento)
+}
+
+// GetHistorico processa a requisição para listar o histórico de status de um agendamento
+func (h *AgendamentoHandler) GetHistorico(c *gin.Context) {
+	// Extrair o ID da requisição
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	historico, err := h.agendamentoService.GetHistorico(id)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar histórico: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, historico)
+}
+
+// CreateSerie processa a criação de uma série de agendamentos recorrentes
+func (h *AgendamentoHandler) CreateSerie(c *gin.Context) {
+	var dto dtos.SerieAgendamentoCreateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.agendamentoService.CreateSerie(&dto)
+	if err != nil {
+		switch err {
+		case errors.ErrNoOccurrencesScheduled:
+			// Informa por que cada ocorrência falhou
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "falhas": response.Falhas})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao criar série de agendamentos: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetSerieByID processa a requisição para buscar uma série de agendamentos e suas ocorrências
+func (h *AgendamentoHandler) GetSerieByID(c *gin.Context) {
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	serie, err := h.agendamentoService.GetSerieByID(id)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Série de agendamentos não encontrada"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar série de agendamentos: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, serie)
+}
+
+// CancelarSerie processa o cancelamento de todas as ocorrências futuras de uma série
+func (h *AgendamentoHandler) CancelarSerie(c *gin.Context) {
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	// O corpo é opcional (apenas o motivo)
+	var dto dtos.SerieAgendamentoCancelDTO
+	if err := c.ShouldBindJSON(&dto); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Identificar quem está cancelando
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	serie, err := h.agendamentoService.CancelarSerie(id, &dto, ator)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Série de agendamentos não encontrada"})
+		case errors.ErrRecurrenceCanceled, errors.ErrAgendamentoVersionConflict:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao cancelar série de agendamentos: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, serie)
+}
+
+// ConfirmarPresenca processa a confirmação de presença do dono em um agendamento
+func (h *AgendamentoHandler) ConfirmarPresenca(c *gin.Context) {
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	// Identificar quem está confirmando
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	agendamento, err := h.agendamentoService.ConfirmarPresenca(id, ator)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+		case errors.ErrOnlyDonoConfirmsPresence:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.ErrAgendamentoUpdateForbidden:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao confirmar presença: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, agendamento)
+}
+
+// GetAgendaFuncionario processa a requisição para listar a agenda de um funcionário do petshop
+func (h *AgendamentoHandler) GetAgendaFuncionario(c *gin.Context) {
+	// Extrair os IDs da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	funcionarioID, err := ksuid.Parse(c.Param("funcionarioId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do funcionário inválido"})
+		return
+	}
+
+	agenda, err := h.agendamentoService.GetAgendaFuncionario(petshopID, funcionarioID, c.Query("de"), c.Query("ate"))
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrFuncionarioNotFound, errors.ErrFuncionarioNotFromPetshop:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Funcionário não encontrado"})
+		case errors.ErrInvalidAgendaPeriod:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar agenda do funcionário: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, agenda)
+}
+
+// GetAgenda processa a requisição para exibir a agenda do petshop por dia e por funcionário
+func (h *AgendamentoHandler) GetAgenda(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	agenda, err := h.agendamentoService.GetAgendaPetshop(petshopID, c.Query("de"), c.Query("ate"))
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidAgendaPeriod:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar agenda: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, agenda)
+}
+
+// AplicarLote processa a requisição para confirmar, cancelar ou deslocar vários agendamentos do petshop
+func (h *AgendamentoHandler) AplicarLote(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("petshopId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	var dto dtos.AgendamentoLoteDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Identificar quem está aplicando o lote
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	resultado, err := h.agendamentoService.AplicarLote(petshopID, &dto, ator)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidBulkOperation, errors.ErrInvalidID:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao aplicar operação em lote: %v", err)})
+		}
+		return
+	}
+
+	// Sem parcial, uma falha impede todo o lote: o relatório indica o que impediu a gravação
+	if !resultado.Aplicado && resultado.Falhas > 0 {
+		c.JSON(http.StatusConflict, resultado)
+		return
+	}
+
+	c.JSON(http.StatusOK, resultado)
+}
+
+// responderConflitoVersao devolve 409 com o estado atual do agendamento e o novo ETag, para o cliente
+// revisar as alterações feitas por outra pessoa
+func (h *AgendamentoHandler) responderConflitoVersao(c *gin.Context, id ksuid.KSUID, err error) {
+	atual, errAtual := h.agendamentoService.GetByID(id)
+	if errAtual != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("ETag", etagVersao(atual.Versao))
+	c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "agendamento": atual})
+}
+
+// AtualizarExecucaoItem processa o registro, pelo petshop, da execução de um serviço do agendamento
+func (h *AgendamentoHandler) AtualizarExecucaoItem(c *gin.Context) {
+	// Extrair os IDs da requisição
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	itemID, err := ksuid.Parse(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do item inválido"})
+		return
+	}
+
+	var dto dtos.ItemExecucaoUpdateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Versão editada pelo cliente (opcional), recebida do ETag de GET /agendamentos/:id
+	versaoEsperada, ok := versaoIfMatch(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cabeçalho If-Match inválido: use o ETag retornado pelo agendamento"})
+		return
+	}
+
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	agendamento, err := h.agendamentoService.AtualizarExecucaoItem(id, itemID, &dto, ator, versaoEsperada)
+	if err != nil {
+		h.responderErroItem(c, id, err)
+		return
+	}
+
+	c.Header("ETag", etagVersao(agendamento.Versao))
+	c.JSON(http.StatusOK, agendamento)
+}
+
+// AdicionarItem processa a inclusão, pelo petshop, de um serviço feito no balcão durante o atendimento
+func (h *AgendamentoHandler) AdicionarItem(c *gin.Context) {
+	// Extrair o ID da requisição
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var dto dtos.ItemAdicionadoCreateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Versão editada pelo cliente (opcional), recebida do ETag de GET /agendamentos/:id
+	versaoEsperada, ok := versaoIfMatch(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cabeçalho If-Match inválido: use o ETag retornado pelo agendamento"})
+		return
+	}
+
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	agendamento, err := h.agendamentoService.AdicionarItem(id, &dto, ator, versaoEsperada)
+	if err != nil {
+		h.responderErroItem(c, id, err)
+		return
+	}
+
+	c.Header("ETag", etagVersao(agendamento.Versao))
+	c.JSON(http.StatusCreated, agendamento)
+}
+
+// responderErroItem converte os erros da alteração de itens do agendamento em respostas HTTP
+func (h *AgendamentoHandler) responderErroItem(c *gin.Context, id ksuid.KSUID, err error) {
+	switch err {
+	case errors.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+	case errors.ErrItemNotFromAgendamento:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.ErrItemExecutionForbidden:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.ErrItemExecutionNotAllowed, errors.ErrHorarioIndisponivel, errors.ErrRecursoIndisponivel,
+		errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable, errors.ErrPetshopClosed:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.ErrAgendamentoVersionConflict:
+		h.responderConflitoVersao(c, id, err)
+	case errors.ErrFailedToUpdateItem, errors.ErrFailedToCheckAgendamento, errors.ErrFailedToCheckService,
+		errors.ErrFailedToFetchPetInfo, errors.ErrFailedToFetchDonoInfo, errors.ErrFailedToFetchPetshopInfo,
+		errors.ErrFailedToFetchResources, errors.ErrFailedToFetchStaff:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// GetTicket processa a requisição do ticket (QR code) de check-in de um agendamento confirmado
+func (h *AgendamentoHandler) GetTicket(c *gin.Context) {
+	// Extrair o ID da requisição
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	ticket, err := h.agendamentoService.GetTicket(id, ator)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+		case errors.ErrTicketForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.ErrTicketNotAvailable:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao emitir ticket: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// CheckIn processa a leitura, pelo petshop, do ticket apresentado na chegada
+func (h *AgendamentoHandler) CheckIn(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("petshopId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	var dto dtos.CheckInDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	agendamento, err := h.agendamentoService.CheckIn(petshopID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidTicket:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.ErrTicketNotFromPetshop:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.ErrCheckInNotAllowed, errors.ErrCheckInOutsideWindow, errors.ErrAlreadyCheckedIn:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao registrar check-in: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, agendamento)
+}
+
+// CheckOut processa o registro, pelo petshop, da saída do pet
+func (h *AgendamentoHandler) CheckOut(c *gin.Context) {
+	// Extrair o ID da requisição
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	agendamento, err := h.agendamentoService.CheckOut(id, ator)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agendamento não encontrado"})
+		case errors.ErrCheckInForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.ErrNotCheckedIn, errors.ErrAlreadyCheckedOut, errors.ErrCheckOutNotAllowed:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao registrar check-out: %v", err)})
+		}
+		return
+	}
+
 	c.JSON(http.StatusOK, agendamento)
 }
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/segmentio/ksuid"
+)
+
+// atorAutenticado extrai das claims do JWT o ID e o tipo do usuário que faz a requisição
+func atorAutenticado(c *gin.Context) (entities.Ator, bool) {
+	claims := jwt.ExtractClaims(c)
+
+	idStr, idExists := claims["id"].(string)
+	tipo, tipoExists := claims["tipo"].(string)
+	if !idExists || !tipoExists {
+		return entities.Ator{}, false
+	}
+
+	id, err := ksuid.Parse(idStr)
+	if err != nil {
+		return entities.Ator{}, false
+	}
+
+	switch entities.TipoAtor(tipo) {
+	case entities.AtorDono, entities.AtorPetshop:
+		return entities.Ator{ID: id, Tipo: entities.TipoAtor(tipo)}, true
+	default:
+		return entities.Ator{}, false
+	}
+}
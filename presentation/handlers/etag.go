@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etagVersao formata a versão de um registro como ETag
+func etagVersao(versao int) string {
+	return `"` + strconv.Itoa(versao) + `"`
+}
+
+// versaoIfMatch extrai do cabeçalho If-Match a versão que o cliente espera atualizar.
+// Retorna zero quando o cabeçalho está ausente ou é "*", e false quando ele não contém uma versão válida
+func versaoIfMatch(c *gin.Context) (int, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, true
+	}
+
+	versao, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+	if err != nil || versao < 1 {
+		return 0, false
+	}
+	return versao, true
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/services"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// FechamentoHandler gerencia as requisições relacionadas ao calendário de fechamentos dos petshops
+type FechamentoHandler struct {
+	fechamentoService *services.FechamentoService
+}
+
+// NewFechamentoHandler cria uma nova instância de FechamentoHandler
+func NewFechamentoHandler(fechamentoService *services.FechamentoService) *FechamentoHandler {
+	return &FechamentoHandler{
+		fechamentoService: fechamentoService,
+	}
+}
+
+// Create processa o cadastro de um fechamento do petshop
+func (h *FechamentoHandler) Create(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("petshopId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	// Extrair dados do body
+	var dto dtos.FechamentoCreateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.fechamentoService.Create(petshopID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidDate, errors.ErrInvalidClosurePeriod, errors.ErrRecurringClosureMustBeFullDay:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao criar fechamento: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetByPetshopID processa a requisição para listar os fechamentos de um petshop
+func (h *FechamentoHandler) GetByPetshopID(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	fechamentos, err := h.fechamentoService.GetByPetshopID(petshopID)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar fechamentos: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, fechamentos)
+}
+
+// Delete processa a exclusão de um fechamento do petshop
+func (h *FechamentoHandler) Delete(c *gin.Context) {
+	// Extrair os IDs da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	fechamentoID, err := ksuid.Parse(c.Param("fechamentoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do fechamento inválido"})
+		return
+	}
+
+	if err := h.fechamentoService.Delete(petshopID, fechamentoID); err != nil {
+		switch err {
+		case errors.ErrClosureNotFound, errors.ErrClosureNotFromPetshop:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fechamento não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao excluir fechamento: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Fechamento excluído com sucesso"})
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/services"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// FuncionarioHandler gerencia as requisições relacionadas aos funcionários dos petshops
+type FuncionarioHandler struct {
+	funcionarioService *services.FuncionarioService
+}
+
+// NewFuncionarioHandler cria uma nova instância de FuncionarioHandler
+func NewFuncionarioHandler(funcionarioService *services.FuncionarioService) *FuncionarioHandler {
+	return &FuncionarioHandler{
+		funcionarioService: funcionarioService,
+	}
+}
+
+// Create processa o cadastro de um funcionário do petshop
+func (h *FuncionarioHandler) Create(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("petshopId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	// Extrair dados do body
+	var dto dtos.FuncionarioCreateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.funcionarioService.Create(petshopID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidID, errors.ErrServiceNotFound, errors.ErrServiceNotFromPetshop:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao cadastrar funcionário: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetByPetshopID processa a requisição para listar os funcionários de um petshop
+func (h *FuncionarioHandler) GetByPetshopID(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	funcionarios, err := h.funcionarioService.GetByPetshopID(petshopID)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar funcionários: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, funcionarios)
+}
+
+// Update processa a atualização de um funcionário do petshop
+func (h *FuncionarioHandler) Update(c *gin.Context) {
+	// Extrair os IDs da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	funcionarioID, err := ksuid.Parse(c.Param("funcionarioId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do funcionário inválido"})
+		return
+	}
+
+	// Extrair dados do body
+	var dto dtos.FuncionarioUpdateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.funcionarioService.Update(petshopID, funcionarioID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrFuncionarioNotFound, errors.ErrFuncionarioNotFromPetshop:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Funcionário não encontrado"})
+		case errors.ErrInvalidID, errors.ErrServiceNotFound, errors.ErrServiceNotFromPetshop:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao atualizar funcionário: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete processa a exclusão de um funcionário do petshop
+func (h *FuncionarioHandler) Delete(c *gin.Context) {
+	// Extrair os IDs da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	funcionarioID, err := ksuid.Parse(c.Param("funcionarioId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do funcionário inválido"})
+		return
+	}
+
+	if err := h.funcionarioService.Delete(petshopID, funcionarioID); err != nil {
+		switch err {
+		case errors.ErrFuncionarioNotFound, errors.ErrFuncionarioNotFromPetshop:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Funcionário não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao excluir funcionário: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Funcionário excluído com sucesso"})
+}
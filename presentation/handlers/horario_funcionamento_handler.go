@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/services"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// HorarioFuncionamentoHandler gerencia as requisições relacionadas ao horário de funcionamento dos petshops
+type HorarioFuncionamentoHandler struct {
+	horarioService *services.HorarioFuncionamentoService
+}
+
+// NewHorarioFuncionamentoHandler cria uma nova instância de HorarioFuncionamentoHandler
+func NewHorarioFuncionamentoHandler(horarioService *services.HorarioFuncionamentoService) *HorarioFuncionamentoHandler {
+	return &HorarioFuncionamentoHandler{
+		horarioService: horarioService,
+	}
+}
+
+// GetByPetshopID processa a requisição para buscar o horário de funcionamento de um petshop
+func (h *HorarioFuncionamentoHandler) GetByPetshopID(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	horario, err := h.horarioService.GetByPetshopID(petshopID)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar horário de funcionamento: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, horario)
+}
+
+// Update processa a substituição da grade semanal de funcionamento de um petshop
+func (h *HorarioFuncionamentoHandler) Update(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	// Extrair dados do body
+	var dto dtos.HorarioFuncionamentoUpdateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	horario, err := h.horarioService.Update(petshopID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidTimeFormat, errors.ErrInvalidBusinessHours, errors.ErrOverlappingBusinessHours:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao atualizar horário de funcionamento: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, horario)
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/services"
+	"github.com/henrygoeszanin/api_petshop/domain/entities"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// ListaEsperaHandler gerencia as requisições relacionadas à lista de espera dos petshops
+type ListaEsperaHandler struct {
+	agendamentoService *services.AgendamentoService
+}
+
+// NewListaEsperaHandler cria uma nova instância de ListaEsperaHandler
+func NewListaEsperaHandler(agendamentoService *services.AgendamentoService) *ListaEsperaHandler {
+	return &ListaEsperaHandler{
+		agendamentoService: agendamentoService,
+	}
+}
+
+// Create processa a entrada de um dono na lista de espera
+func (h *ListaEsperaHandler) Create(c *gin.Context) {
+	var dto dtos.ListaEsperaCreateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Identificar o dono que está entrando na lista de espera
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return
+	}
+
+	response, err := h.agendamentoService.CreateListaEspera(&dto, ator)
+	if err != nil {
+		switch err {
+		case errors.ErrOnlyDonoJoinsWaitlist:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao entrar na lista de espera: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetByID processa a requisição para buscar uma entrada da lista de espera
+func (h *ListaEsperaHandler) GetByID(c *gin.Context) {
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	entrada, err := h.agendamentoService.GetListaEsperaByID(id)
+	if err != nil {
+		switch err {
+		case errors.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Entrada da lista de espera não encontrada"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar entrada da lista de espera: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entrada)
+}
+
+// GetByDonoID processa a requisição para listar as entradas da lista de espera de um dono
+func (h *ListaEsperaHandler) GetByDonoID(c *gin.Context) {
+	donoID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de dono inválido"})
+		return
+	}
+
+	entradas, err := h.agendamentoService.GetListaEsperaByDonoID(donoID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar lista de espera: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, entradas)
+}
+
+// GetByPetshopID processa a requisição para listar a lista de espera de um petshop
+func (h *ListaEsperaHandler) GetByPetshopID(c *gin.Context) {
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de petshop inválido"})
+		return
+	}
+
+	entradas, err := h.agendamentoService.GetListaEsperaByPetshopID(petshopID)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar lista de espera: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entradas)
+}
+
+// AceitarOferta processa a aceitação do horário ofertado, criando o agendamento
+func (h *ListaEsperaHandler) AceitarOferta(c *gin.Context) {
+	id, ator, ok := idEAtor(c)
+	if !ok {
+		return
+	}
+
+	agendamento, err := h.agendamentoService.AceitarOferta(id, ator)
+	if err != nil {
+		h.responderErroGestao(c, err, "Erro ao aceitar oferta")
+		return
+	}
+
+	c.JSON(http.StatusCreated, agendamento)
+}
+
+// RecusarOferta processa a recusa do horário ofertado
+func (h *ListaEsperaHandler) RecusarOferta(c *gin.Context) {
+	id, ator, ok := idEAtor(c)
+	if !ok {
+		return
+	}
+
+	entrada, err := h.agendamentoService.RecusarOferta(id, ator)
+	if err != nil {
+		h.responderErroGestao(c, err, "Erro ao recusar oferta")
+		return
+	}
+
+	c.JSON(http.StatusOK, entrada)
+}
+
+// Cancelar processa a saída do dono da lista de espera
+func (h *ListaEsperaHandler) Cancelar(c *gin.Context) {
+	id, ator, ok := idEAtor(c)
+	if !ok {
+		return
+	}
+
+	entrada, err := h.agendamentoService.CancelarEntrada(id, ator)
+	if err != nil {
+		h.responderErroGestao(c, err, "Erro ao sair da lista de espera")
+		return
+	}
+
+	c.JSON(http.StatusOK, entrada)
+}
+
+// idEAtor extrai o ID da entrada da URL e o usuário autenticado, respondendo em caso de erro
+func idEAtor(c *gin.Context) (ksuid.KSUID, entities.Ator, bool) {
+	id, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return ksuid.Nil, entities.Ator{}, false
+	}
+
+	ator, ok := atorAutenticado(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido ou expirado"})
+		return ksuid.Nil, entities.Ator{}, false
+	}
+	return id, ator, true
+}
+
+// responderErroGestao converte os erros de aceitar, recusar e cancelar em respostas HTTP
+func (h *ListaEsperaHandler) responderErroGestao(c *gin.Context, err error, contexto string) {
+	switch err {
+	case errors.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Entrada da lista de espera não encontrada"})
+	case errors.ErrOnlyDonoManagesWaitlist:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.ErrWaitlistNotOffered, errors.ErrWaitlistOfferExpired, errors.ErrWaitlistEntryClosed,
+		errors.ErrHorarioIndisponivel, errors.ErrPetshopClosed, errors.ErrOutsideBusinessHours, errors.ErrPastDate,
+		errors.ErrRecursoIndisponivel, errors.ErrFuncionarioIndisponivel, errors.ErrNoStaffAvailable:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %v", contexto, err)})
+	}
+}
@@ -96,6 +96,8 @@ func (h *PetshopHandler) Update(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
 		case errors.ErrAlreadyExists:
 			c.JSON(http.StatusConflict, gin.H{"error": "Email já cadastrado"})
+		case errors.ErrInvalidCancelPolicy:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao atualizar petshop: %v", err)})
 		}
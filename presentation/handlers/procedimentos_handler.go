@@ -30,6 +30,9 @@ func (h *ProcedimentoHandler) Create(c *gin.Context) {
 		return
 	}
 
+	// O petshop do procedimento é sempre o da URL (já validado pelo middleware)
+	dto.PetshopID = c.Param("petshopId")
+
 	response, err := h.procedimentoService.Create(&dto)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Erro ao criar procedimento: %v", err)})
@@ -42,7 +45,7 @@ func (h *ProcedimentoHandler) Create(c *gin.Context) {
 // GetByPetID processa a requisição para listar os procedimentos de um pet
 func (h *ProcedimentoHandler) GetByPetID(c *gin.Context) {
 	// Extrair o ID do pet da requisição
-	petIDStr := c.Param("petId")
+	petIDStr := c.Param("id")
 	petID, err := ksuid.Parse(petIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pet inválido"})
@@ -58,3 +61,22 @@ func (h *ProcedimentoHandler) GetByPetID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, procedimentos)
 }
+
+// GetByPetshopID processa a requisição para listar os procedimentos realizados por um petshop
+func (h *ProcedimentoHandler) GetByPetshopID(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	// Buscar procedimentos do petshop
+	procedimentos, err := h.procedimentoService.GetByPetshopID(petshopID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar procedimentos: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, procedimentos)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/application/dtos"
+	"github.com/henrygoeszanin/api_petshop/application/services"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+	"github.com/segmentio/ksuid"
+)
+
+// RecursoHandler gerencia as requisições relacionadas aos recursos físicos dos petshops
+// e aos recursos consumidos por cada serviço
+type RecursoHandler struct {
+	recursoService *services.RecursoService
+}
+
+// NewRecursoHandler cria uma nova instância de RecursoHandler
+func NewRecursoHandler(recursoService *services.RecursoService) *RecursoHandler {
+	return &RecursoHandler{
+		recursoService: recursoService,
+	}
+}
+
+// Create processa o cadastro de um recurso físico do petshop
+func (h *RecursoHandler) Create(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("petshopId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	// Extrair dados do body
+	var dto dtos.RecursoCreateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.recursoService.Create(petshopID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		case errors.ErrInvalidResourceQuantity:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao cadastrar recurso: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetByPetshopID processa a requisição para listar os recursos de um petshop
+func (h *RecursoHandler) GetByPetshopID(c *gin.Context) {
+	// Extrair o ID do petshop da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	recursos, err := h.recursoService.GetByPetshopID(petshopID)
+	if err != nil {
+		switch err {
+		case errors.ErrPetshopNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao buscar recursos: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recursos)
+}
+
+// Update processa a atualização de um recurso do petshop
+func (h *RecursoHandler) Update(c *gin.Context) {
+	// Extrair os IDs da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	recursoID, err := ksuid.Parse(c.Param("recursoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do recurso inválido"})
+		return
+	}
+
+	// Extrair dados do body
+	var dto dtos.RecursoUpdateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.recursoService.Update(petshopID, recursoID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrRecursoNotFound, errors.ErrRecursoNotFromPetshop:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Recurso não encontrado"})
+		case errors.ErrInvalidResourceQuantity:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao atualizar recurso: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete processa a exclusão de um recurso do petshop
+func (h *RecursoHandler) Delete(c *gin.Context) {
+	// Extrair os IDs da requisição
+	petshopID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do petshop inválido"})
+		return
+	}
+
+	recursoID, err := ksuid.Parse(c.Param("recursoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do recurso inválido"})
+		return
+	}
+
+	if err := h.recursoService.Delete(petshopID, recursoID); err != nil {
+		switch err {
+		case errors.ErrRecursoNotFound, errors.ErrRecursoNotFromPetshop:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Recurso não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao excluir recurso: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Recurso excluído com sucesso"})
+}
+
+// DefinirConsumos processa a definição dos recursos físicos consumidos por um serviço
+func (h *RecursoHandler) DefinirConsumos(c *gin.Context) {
+	// Extrair o ID do serviço da requisição
+	servicoID, err := ksuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do serviço inválido"})
+		return
+	}
+
+	// Extrair dados do body
+	var dto dtos.ServicoConsumosDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recursos, err := h.recursoService.DefinirConsumos(servicoID, &dto)
+	if err != nil {
+		switch err {
+		case errors.ErrServiceNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Serviço não encontrado"})
+		case errors.ErrInvalidID, errors.ErrRecursoNotFound, errors.ErrRecursoNotFromPetshop, errors.ErrInvalidResourceUsage:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao definir recursos do serviço: %v", err)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recursos)
+}
@@ -48,6 +48,8 @@ func (h *ServicoHandler) Create(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Petshop não encontrado"})
 		case errors.ErrAlreadyExists:
 			c.JSON(http.StatusConflict, gin.H{"error": "Já existe um serviço com este nome neste petshop"})
+		case errors.ErrInvalidServiceDuration:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao criar serviço: %v", err)})
 		}
@@ -132,6 +134,8 @@ func (h *ServicoHandler) Update(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Serviço não encontrado"})
 		case errors.ErrAlreadyExists:
 			c.JSON(http.StatusConflict, gin.H{"error": "Já existe um serviço com este nome neste petshop"})
+		case errors.ErrInvalidServiceDuration:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Erro ao atualizar serviço: %v", err)})
 		}
@@ -93,3 +93,136 @@ func AgendamentoOwnershipRequired() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// SerieOwnershipRequired verifica se o usuário autenticado é o dono da série de agendamentos ou o petshop associado
+func SerieOwnershipRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Verificar se o serviço foi configurado
+		if agendamentoServiceInstance == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Serviço de agendamento não configurado"})
+			c.Abort()
+			return
+		}
+
+		// Extrai as claims do token JWT
+		claims := jwt.ExtractClaims(c)
+
+		// Extrai o ID da série da URL
+		serieID, err := ksuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da série inválido"})
+			c.Abort()
+			return
+		}
+
+		// Busca a série
+		serie, err := agendamentoServiceInstance.GetSerieByID(serieID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Série de agendamentos não encontrada"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar série de agendamentos"})
+			}
+			c.Abort()
+			return
+		}
+
+		// Extrai o ID do usuário autenticado
+		userIDStr, exists := claims["id"].(string)
+		if !exists {
+			c.JSON(http.StatusForbidden, gin.H{"error": "ID do usuário não encontrado no token"})
+			c.Abort()
+			return
+		}
+
+		// Verifica o acesso com base no tipo de usuário
+		switch claims["tipo"] {
+		case "dono":
+			if userIDStr != serie.DonoID {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Você não tem permissão para acessar esta série de agendamentos"})
+				c.Abort()
+				return
+			}
+		case "petshop":
+			if userIDStr != serie.PetshopID {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Esta série de agendamentos não pertence ao seu petshop"})
+				c.Abort()
+				return
+			}
+		default:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Tipo de usuário não autorizado"})
+			c.Abort()
+			return
+		}
+
+		// Se passou por todas as verificações, o usuário pode acessar a série
+		c.Next()
+	}
+}
+
+// ListaEsperaOwnershipRequired verifica se o usuário autenticado é o dono da entrada da lista de espera
+// ou o petshop em que ela foi feita
+func ListaEsperaOwnershipRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Verificar se o serviço foi configurado
+		if agendamentoServiceInstance == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Serviço de agendamento não configurado"})
+			c.Abort()
+			return
+		}
+
+		// Extrai as claims do token JWT
+		claims := jwt.ExtractClaims(c)
+
+		// Extrai o ID da entrada da URL
+		entradaID, err := ksuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da entrada da lista de espera inválido"})
+			c.Abort()
+			return
+		}
+
+		// Busca a entrada
+		entrada, err := agendamentoServiceInstance.GetListaEsperaByID(entradaID)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Entrada da lista de espera não encontrada"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar entrada da lista de espera"})
+			}
+			c.Abort()
+			return
+		}
+
+		// Extrai o ID do usuário autenticado
+		userIDStr, exists := claims["id"].(string)
+		if !exists {
+			c.JSON(http.StatusForbidden, gin.H{"error": "ID do usuário não encontrado no token"})
+			c.Abort()
+			return
+		}
+
+		// Verifica o acesso com base no tipo de usuário
+		switch claims["tipo"] {
+		case "dono":
+			if userIDStr != entrada.DonoID {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Você não tem permissão para acessar esta entrada da lista de espera"})
+				c.Abort()
+				return
+			}
+		case "petshop":
+			if userIDStr != entrada.PetshopID {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Esta entrada da lista de espera não pertence ao seu petshop"})
+				c.Abort()
+				return
+			}
+		default:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Tipo de usuário não autorizado"})
+			c.Abort()
+			return
+		}
+
+		// Se passou por todas as verificações, o usuário pode acessar a entrada
+		c.Next()
+	}
+}
@@ -0,0 +1,118 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/application/services"
+	"github.com/henrygoeszanin/api_petshop/domain/errors"
+)
+
+// serviço global para uso nos middlewares
+var idempotenciaServiceInstance *services.IdempotenciaService
+
+// SetIdempotenciaService configura o serviço de idempotência para uso nos middlewares
+func SetIdempotenciaService(s *services.IdempotenciaService) {
+	idempotenciaServiceInstance = s
+}
+
+// respostaGravada repassa a resposta ao cliente e guarda uma cópia do corpo
+type respostaGravada struct {
+	gin.ResponseWriter
+	corpo bytes.Buffer
+}
+
+func (w *respostaGravada) Write(b []byte) (int, error) {
+	w.corpo.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *respostaGravada) WriteString(s string) (int, error) {
+	w.corpo.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyKey honra o cabeçalho Idempotency-Key em rotas de criação autenticadas.
+// A primeira resposta de cada chave do usuário é guardada e reenviada nas novas tentativas, com o
+// cabeçalho Idempotent-Replayed. Sem o cabeçalho, a requisição segue normalmente
+func IdempotencyKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		chave := c.GetHeader("Idempotency-Key")
+		if chave == "" {
+			c.Next()
+			return
+		}
+
+		// Verificar se o serviço foi configurado
+		if idempotenciaServiceInstance == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Serviço de idempotência não configurado"})
+			c.Abort()
+			return
+		}
+
+		// A chave pertence ao usuário autenticado
+		claims := jwt.ExtractClaims(c)
+		usuarioID, exists := claims["id"].(string)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "ID do usuário não encontrado no token"})
+			c.Abort()
+			return
+		}
+
+		// Ler o corpo para compará-lo com o da requisição original e devolvê-lo ao handler
+		corpo, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao ler o corpo da requisição"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(corpo))
+
+		rota := c.Request.Method + " " + c.Request.URL.Path
+		registro, repetir, err := idempotenciaServiceInstance.Iniciar(usuarioID, chave, rota, corpo)
+		if err != nil {
+			switch err {
+			case errors.ErrInvalidIdempotencyKey:
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			case errors.ErrIdempotencyKeyReused:
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			case errors.ErrIdempotencyKeyInProgress:
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			c.Abort()
+			return
+		}
+
+		// Nova tentativa: reenviar a resposta guardada sem executar o handler
+		if repetir {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(registro.StatusCode, "application/json; charset=utf-8", registro.Resposta)
+			c.Abort()
+			return
+		}
+
+		// Se o handler entrar em pânico, a chave é liberada para uma nova tentativa
+		defer func() {
+			if r := recover(); r != nil {
+				if err := idempotenciaServiceInstance.Liberar(registro); err != nil {
+					log.Printf("Erro ao liberar Idempotency-Key: %v", err)
+				}
+				panic(r)
+			}
+		}()
+
+		gravada := &respostaGravada{ResponseWriter: c.Writer}
+		c.Writer = gravada
+		c.Next()
+
+		if err := idempotenciaServiceInstance.Concluir(registro, gravada.Status(), gravada.corpo.Bytes()); err != nil {
+			log.Printf("Erro ao guardar resposta da Idempotency-Key: %v", err)
+		}
+	}
+}
@@ -45,7 +45,7 @@ func PetOwnershipRequired() gin.HandlerFunc {
 		}
 
 		// Extrai o ID do pet da URL
-		petIDStr := c.Param("petId")
+		petIDStr := c.Param("id")
 		petID, err := ksuid.Parse(petIDStr)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pet inválido"})
@@ -16,19 +16,60 @@ func SetupAgendamentoRoutes(router *gin.Engine, agendamentoHandler *handlers.Age
 		protected := agendamentos.Group("/")
 		protected.Use(authMiddleware.MiddlewareFunc())
 		{ // POST /agendamentos - Criar um novo agendamento
-			protected.POST("", agendamentoHandler.Create)
+			// Aceita Idempotency-Key: novas tentativas com a mesma chave recebem a resposta original
+			protected.POST("", middlewares.IdempotencyKey(), agendamentoHandler.Create)
 
-			// GET /agendamentos/:id - Buscar agendamento por ID
+			// GET /agendamentos/:id - Buscar agendamento por ID (com ETag da versão atual)
 			// Requer verificação de propriedade (dono ou petshop associado)
 			protected.GET("/:id", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.GetByID)
 
 			// PUT /agendamentos/:id - Atualizar agendamento
 			// Requer verificação de propriedade (dono ou petshop associado)
-			agendamentos.PUT("/:id", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.Update)
+			// Aceita If-Match com o ETag de GET /agendamentos/:id; versão desatualizada retorna 409 com o estado atual
+			protected.PUT("/:id", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.Update)
 
 			// PUT /agendamentos/:id/status - Atualizar status do agendamento
 			// Requer verificação de propriedade (dono ou petshop associado)
-			agendamentos.PUT("/:id/status", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.UpdateStatus)
+			// As transições permitidas dependem do tipo de usuário (dono apenas cancela; só o petshop registra falta)
+			protected.PUT("/:id/status", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.UpdateStatus)
+
+			// POST /agendamentos/:id/remarcar - Mover o agendamento para outra data (mantém serviços e preços)
+			// Requer verificação de propriedade (dono ou petshop associado)
+			// A data anterior e a nova ficam registradas no histórico
+			protected.POST("/:id/remarcar", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.Remarcar)
+
+			// GET /agendamentos/:id/historico - Histórico de mudanças de status e remarcações (quem, quando e por quê)
+			// Requer verificação de propriedade (dono ou petshop associado)
+			protected.GET("/:id/historico", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.GetHistorico)
+
+			// POST /agendamentos/:id/confirmar-presenca - Dono confirma que comparecerá
+			// Exigido pelo petshop de donos com muitas faltas antes de confirmar o agendamento
+			protected.POST("/:id/confirmar-presenca", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.ConfirmarPresenca)
+
+			// PUT /agendamentos/:id/itens/:itemId - Petshop registra se o serviço foi executado ou pulado e o preço final
+			// POST /agendamentos/:id/itens - Petshop inclui um serviço feito no balcão
+			// Apenas em agendamentos confirmados; aceitam If-Match com o ETag de GET /agendamentos/:id
+			protected.PUT("/:id/itens/:itemId", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.AtualizarExecucaoItem)
+			protected.POST("/:id/itens", middlewares.AgendamentoOwnershipRequired(), middlewares.IdempotencyKey(), agendamentoHandler.AdicionarItem)
+
+			// GET /agendamentos/:id/ticket - Ticket assinado de um agendamento confirmado, exibido como QR code na chegada
+			// Requer verificação de propriedade (dono ou petshop associado)
+			protected.GET("/:id/ticket", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.GetTicket)
+
+			// POST /agendamentos/:id/check-out - Petshop registra a saída do pet, após o check-in
+			protected.POST("/:id/check-out", middlewares.AgendamentoOwnershipRequired(), agendamentoHandler.CheckOut)
+
+			// POST /agendamentos/series - Criar agendamentos recorrentes (semanal, quinzenal ou mensal)
+			// Cada ocorrência é um agendamento comum; uma única ocorrência é cancelada via PUT /agendamentos/:id/status
+			protected.POST("/series", middlewares.IdempotencyKey(), agendamentoHandler.CreateSerie)
+
+			// GET /agendamentos/series/:id - Buscar série e suas ocorrências
+			// Requer verificação de propriedade (dono ou petshop associado)
+			protected.GET("/series/:id", middlewares.SerieOwnershipRequired(), agendamentoHandler.GetSerieByID)
+
+			// POST /agendamentos/series/:id/cancelar - Cancelar todas as ocorrências futuras da série
+			// Requer verificação de propriedade (dono ou petshop associado)
+			protected.POST("/series/:id/cancelar", middlewares.SerieOwnershipRequired(), agendamentoHandler.CancelarSerie)
 		}
 	}
 
@@ -39,7 +80,9 @@ func SetupAgendamentoRoutes(router *gin.Engine, agendamentoHandler *handlers.Age
 		protected := donos.Group("/")
 		protected.Use(authMiddleware.MiddlewareFunc())
 		{
-			// GET /donos/:donoId/agendamentos - Listar todos os agendamentos de um dono
+			// GET /donos/:id/agendamentos - Listar agendamentos de um dono (paginado)
+			// Filtros: de, ate (AAAA-MM-DD), status (separados por vírgula), pet_id, servico_id
+			// Ordenação: ordenar (data_agendada, created_at, total_previsto, status) e ordem (asc, desc); paginação: page, limit
 			// Middleware verifica se o usuário autenticado é o próprio dono
 			protected.GET("/:id/agendamentos", middlewares.DonoOwnershipRequired(), agendamentoHandler.GetByDonoID)
 		}
@@ -48,13 +91,42 @@ func SetupAgendamentoRoutes(router *gin.Engine, agendamentoHandler *handlers.Age
 	// Rota para listar agendamentos de um petshop
 	petshops := router.Group("/petshops")
 	{
+		// GET /petshops/:id/disponibilidade?data=AAAA-MM-DD&servicos=id1,id2 - Horários livres (público)
+		petshops.GET("/:id/disponibilidade", agendamentoHandler.GetDisponibilidade)
+
 		// Rotas protegidas (requerem autenticação)
 		protected := petshops.Group("/")
 		protected.Use(authMiddleware.MiddlewareFunc())
 		{
-			// GET /petshops/:petshopId/agendamentos - Listar todos os agendamentos de um petshop
+			// GET /petshops/:id/agendamentos - Listar agendamentos de um petshop (paginado)
+			// Aceita os mesmos filtros, ordenação e paginação de GET /donos/:id/agendamentos
 			// Middleware verifica se o usuário autenticado é o próprio petshop
 			protected.GET("/:id/agendamentos", middlewares.PetshopOwnershipRequired(), agendamentoHandler.GetByPetshopID)
+
+			// POST /petshops/:petshopId/agendamentos - Agendamento feito pelo petshop (canal telefone ou balcao)
+			// Para um dono cadastrado (dono_id e pet_id) ou um cliente sem conta (cliente)
+			protected.POST("/:petshopId/agendamentos", middlewares.PetshopOwnershipFromParamRequired("petshopId"), middlewares.IdempotencyKey(), agendamentoHandler.CreatePeloPetshop)
+
+			// POST /petshops/:petshopId/clientes-avulsos/:clienteId/vincular - Vincular o cliente avulso a um dono cadastrado
+			// O petshop confirma a identidade; o dono precisa ter o email ou o telefone do cliente
+			protected.POST("/:petshopId/clientes-avulsos/:clienteId/vincular", middlewares.PetshopOwnershipFromParamRequired("petshopId"), agendamentoHandler.VincularClienteAvulso)
+
+			// POST /petshops/:petshopId/agendamentos/lote - Confirmar, cancelar ou deslocar vários agendamentos
+			// Seleciona por ids ou por período (inicio/fim, opcionalmente de um funcionário); as alterações são
+			// gravadas em uma transação e, sem "parcial", nenhuma é aplicada se alguma falhar
+			protected.POST("/:petshopId/agendamentos/lote", middlewares.PetshopOwnershipFromParamRequired("petshopId"), agendamentoHandler.AplicarLote)
+
+			// POST /petshops/:petshopId/check-in - Registrar a chegada lendo o ticket (QR code) do agendamento
+			// O ticket precisa ser de um agendamento confirmado do próprio petshop, no dia do agendamento
+			protected.POST("/:petshopId/check-in", middlewares.PetshopOwnershipFromParamRequired("petshopId"), agendamentoHandler.CheckIn)
+
+			// GET /petshops/:id/agenda?de=AAAA-MM-DD&ate=AAAA-MM-DD - Agenda do petshop por dia e por funcionário
+			// Sem datas, retorna os próximos 7 dias; o período é limitado a 31 dias. Cancelados não aparecem
+			protected.GET("/:id/agenda", middlewares.PetshopOwnershipRequired(), agendamentoHandler.GetAgenda)
+
+			// GET /petshops/:id/funcionarios/:funcionarioId/agenda?de=AAAA-MM-DD&ate=AAAA-MM-DD - Agenda do funcionário
+			// Sem datas, retorna os próximos 7 dias; o período é limitado a 31 dias
+			protected.GET("/:id/funcionarios/:funcionarioId/agenda", middlewares.PetshopOwnershipRequired(), agendamentoHandler.GetAgendaFuncionario)
 		}
 	}
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/presentation/handlers"
+	"github.com/henrygoeszanin/api_petshop/presentation/middlewares"
+)
+
+// SetupFechamentoRoutes configura as rotas para o calendário de fechamentos dos petshops
+func SetupFechamentoRoutes(router *gin.Engine, fechamentoHandler *handlers.FechamentoHandler, authMiddleware *jwt.GinJWTMiddleware) {
+	petshops := router.Group("/petshops")
+	{
+		// GET /petshops/:id/fechamentos - Listar feriados e fechamentos (público)
+		petshops.GET("/:id/fechamentos", fechamentoHandler.GetByPetshopID)
+
+		// Rotas protegidas (requerem autenticação)
+		// Apenas o próprio petshop pode gerenciar seu calendário de fechamentos
+		protected := petshops.Group("/")
+		protected.Use(authMiddleware.MiddlewareFunc())
+		{
+			// POST /petshops/:petshopId/fechamentos - Cadastrar fechamento e sinalizar agendamentos afetados
+			// O parâmetro usa o mesmo nome das demais rotas POST sob /petshops (exigência do roteador do Gin)
+			protected.POST("/:petshopId/fechamentos", middlewares.PetshopOwnershipFromParamRequired("petshopId"), middlewares.IdempotencyKey(), fechamentoHandler.Create)
+
+			// DELETE /petshops/:id/fechamentos/:fechamentoId - Excluir fechamento
+			protected.DELETE("/:id/fechamentos/:fechamentoId", middlewares.PetshopOwnershipRequired(), fechamentoHandler.Delete)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/presentation/handlers"
+	"github.com/henrygoeszanin/api_petshop/presentation/middlewares"
+)
+
+// SetupFuncionarioRoutes configura as rotas para os funcionários dos petshops
+func SetupFuncionarioRoutes(router *gin.Engine, funcionarioHandler *handlers.FuncionarioHandler, authMiddleware *jwt.GinJWTMiddleware) {
+	petshops := router.Group("/petshops")
+	{
+		// GET /petshops/:id/funcionarios - Listar funcionários e os serviços que realizam (público)
+		petshops.GET("/:id/funcionarios", funcionarioHandler.GetByPetshopID)
+
+		// Rotas protegidas (requerem autenticação)
+		// Apenas o próprio petshop pode gerenciar seus funcionários
+		protected := petshops.Group("/")
+		protected.Use(authMiddleware.MiddlewareFunc())
+		{
+			// POST /petshops/:petshopId/funcionarios - Cadastrar funcionário
+			// O parâmetro usa o mesmo nome das demais rotas POST sob /petshops (exigência do roteador do Gin)
+			protected.POST("/:petshopId/funcionarios", middlewares.PetshopOwnershipFromParamRequired("petshopId"), middlewares.IdempotencyKey(), funcionarioHandler.Create)
+
+			// PUT /petshops/:id/funcionarios/:funcionarioId - Atualizar nome, situação e serviços do funcionário
+			protected.PUT("/:id/funcionarios/:funcionarioId", middlewares.PetshopOwnershipRequired(), funcionarioHandler.Update)
+
+			// DELETE /petshops/:id/funcionarios/:funcionarioId - Excluir funcionário
+			protected.DELETE("/:id/funcionarios/:funcionarioId", middlewares.PetshopOwnershipRequired(), funcionarioHandler.Delete)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/presentation/handlers"
+	"github.com/henrygoeszanin/api_petshop/presentation/middlewares"
+)
+
+// SetupHorarioFuncionamentoRoutes configura as rotas para o horário de funcionamento dos petshops
+func SetupHorarioFuncionamentoRoutes(router *gin.Engine, horarioHandler *handlers.HorarioFuncionamentoHandler, authMiddleware *jwt.GinJWTMiddleware) {
+	petshops := router.Group("/petshops")
+	{
+		// GET /petshops/:id/horarios - Consultar horário de funcionamento (público)
+		petshops.GET("/:id/horarios", horarioHandler.GetByPetshopID)
+
+		// Rotas protegidas (requerem autenticação)
+		protected := petshops.Group("/")
+		protected.Use(authMiddleware.MiddlewareFunc())
+		{
+			// PUT /petshops/:id/horarios - Substituir a grade semanal de funcionamento
+			// Apenas o próprio petshop pode alterar seu horário
+			protected.PUT("/:id/horarios", middlewares.PetshopOwnershipRequired(), horarioHandler.Update)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package routes
+
+import (
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/presentation/handlers"
+	"github.com/henrygoeszanin/api_petshop/presentation/middlewares"
+)
+
+// SetupListaEsperaRoutes configura as rotas para operações relacionadas à lista de espera
+func SetupListaEsperaRoutes(router *gin.Engine, listaEsperaHandler *handlers.ListaEsperaHandler, authMiddleware *jwt.GinJWTMiddleware) {
+	// Grupo de rotas para a lista de espera
+	listaEspera := router.Group("/lista-espera")
+	{
+		// Todas as rotas da lista de espera requerem autenticação
+		protected := listaEspera.Group("/")
+		protected.Use(authMiddleware.MiddlewareFunc())
+		{
+			// POST /lista-espera - Entrar na lista de espera de um petshop para uma janela de datas
+			// Quando um agendamento sobreposto é cancelado, o horário é ofertado e fica reservado por tempo limitado
+			protected.POST("", middlewares.IdempotencyKey(), listaEsperaHandler.Create)
+
+			// GET /lista-espera/:id - Buscar entrada da lista de espera (com a oferta ativa, se houver)
+			// Requer verificação de propriedade (dono ou petshop associado)
+			protected.GET("/:id", middlewares.ListaEsperaOwnershipRequired(), listaEsperaHandler.GetByID)
+
+			// POST /lista-espera/:id/aceitar - Dono aceita a oferta e o agendamento é criado
+			// Requer verificação de propriedade (apenas o dono gerencia a entrada)
+			protected.POST("/:id/aceitar", middlewares.ListaEsperaOwnershipRequired(), listaEsperaHandler.AceitarOferta)
+
+			// POST /lista-espera/:id/recusar - Dono recusa a oferta, que passa para o próximo da fila
+			// Requer verificação de propriedade (apenas o dono gerencia a entrada)
+			protected.POST("/:id/recusar", middlewares.ListaEsperaOwnershipRequired(), listaEsperaHandler.RecusarOferta)
+
+			// DELETE /lista-espera/:id - Dono sai da lista de espera
+			// Requer verificação de propriedade (apenas o dono gerencia a entrada)
+			protected.DELETE("/:id", middlewares.ListaEsperaOwnershipRequired(), listaEsperaHandler.Cancelar)
+		}
+	}
+
+	// Rota para listar as entradas de um dono
+	donos := router.Group("/donos")
+	{
+		protected := donos.Group("/")
+		protected.Use(authMiddleware.MiddlewareFunc())
+		{
+			// GET /donos/:id/lista-espera - Listar as entradas do dono na lista de espera
+			// Middleware verifica se o usuário autenticado é o próprio dono
+			protected.GET("/:id/lista-espera", middlewares.DonoOwnershipRequired(), listaEsperaHandler.GetByDonoID)
+		}
+	}
+
+	// Rota para listar a lista de espera de um petshop
+	petshops := router.Group("/petshops")
+	{
+		protected := petshops.Group("/")
+		protected.Use(authMiddleware.MiddlewareFunc())
+		{
+			// GET /petshops/:id/lista-espera - Listar a lista de espera do petshop por ordem de chegada
+			// Middleware verifica se o usuário autenticado é o próprio petshop
+			protected.GET("/:id/lista-espera", middlewares.PetshopOwnershipRequired(), listaEsperaHandler.GetByPetshopID)
+		}
+	}
+}
@@ -17,7 +17,7 @@ func SetupPetRoutes(router *gin.Engine, petHandler *handlers.PetHandler, authMid
 		protected.Use(authMiddleware.MiddlewareFunc())
 		{
 			// POST /pets - Criar um novo pet
-			protected.POST("", petHandler.Create)
+			protected.POST("", middlewares.IdempotencyKey(), petHandler.Create)
 
 			// GET /pets/:id - Retornar dados do pet por ID
 			protected.GET(":id", petHandler.GetByID)
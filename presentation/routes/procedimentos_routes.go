@@ -0,0 +1,32 @@
+package routes
+
+import (
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/presentation/handlers"
+	"github.com/henrygoeszanin/api_petshop/presentation/middlewares"
+)
+
+// SetupProcedimentoRoutes configura as rotas para operações relacionadas a procedimentos
+func SetupProcedimentoRoutes(router *gin.Engine, procedimentoHandler *handlers.ProcedimentoHandler, authMiddleware *jwt.GinJWTMiddleware) {
+	// Rotas do petshop (requerem autenticação)
+	petshops := router.Group("/petshops")
+	petshops.Use(authMiddleware.MiddlewareFunc())
+	{
+		// POST /petshops/:petshopId/procedimentos - Registrar um procedimento realizado
+		// Apenas o próprio petshop pode registrar procedimentos
+		petshops.POST("/:petshopId/procedimentos", middlewares.PetshopOwnershipFromParamRequired("petshopId"), middlewares.IdempotencyKey(), procedimentoHandler.Create)
+
+		// GET /petshops/:id/procedimentos - Listar procedimentos realizados pelo petshop
+		petshops.GET("/:id/procedimentos", middlewares.PetshopOwnershipRequired(), procedimentoHandler.GetByPetshopID)
+	}
+
+	// Rotas do dono (requerem autenticação)
+	pets := router.Group("/pets")
+	pets.Use(authMiddleware.MiddlewareFunc())
+	{
+		// GET /pets/:id/procedimentos - Histórico de procedimentos do pet
+		// Apenas o dono do pet pode consultar
+		pets.GET("/:id/procedimentos", middlewares.PetOwnershipRequired(), procedimentoHandler.GetByPetID)
+	}
+}
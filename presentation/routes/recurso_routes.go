@@ -0,0 +1,41 @@
+package routes
+
+import (
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/henrygoeszanin/api_petshop/presentation/handlers"
+	"github.com/henrygoeszanin/api_petshop/presentation/middlewares"
+)
+
+// SetupRecursoRoutes configura as rotas para os recursos físicos dos petshops (banheiras, mesas, baias)
+func SetupRecursoRoutes(router *gin.Engine, recursoHandler *handlers.RecursoHandler, authMiddleware *jwt.GinJWTMiddleware) {
+	petshops := router.Group("/petshops")
+	{
+		// GET /petshops/:id/recursos - Listar recursos e os serviços que consomem cada um (público)
+		petshops.GET("/:id/recursos", recursoHandler.GetByPetshopID)
+
+		// Rotas protegidas (requerem autenticação)
+		// Apenas o próprio petshop pode gerenciar seus recursos
+		protected := petshops.Group("/")
+		protected.Use(authMiddleware.MiddlewareFunc())
+		{
+			// POST /petshops/:petshopId/recursos - Cadastrar recurso e a quantidade disponível
+			// O parâmetro usa o mesmo nome das demais rotas POST sob /petshops (exigência do roteador do Gin)
+			protected.POST("/:petshopId/recursos", middlewares.PetshopOwnershipFromParamRequired("petshopId"), middlewares.IdempotencyKey(), recursoHandler.Create)
+
+			// PUT /petshops/:id/recursos/:recursoId - Atualizar nome e quantidade do recurso
+			protected.PUT("/:id/recursos/:recursoId", middlewares.PetshopOwnershipRequired(), recursoHandler.Update)
+
+			// DELETE /petshops/:id/recursos/:recursoId - Excluir recurso
+			protected.DELETE("/:id/recursos/:recursoId", middlewares.PetshopOwnershipRequired(), recursoHandler.Delete)
+		}
+	}
+
+	// PUT /servicos/:id/recursos - Definir quais recursos o serviço consome e por quanto tempo
+	// Substitui a lista anterior; apenas o petshop dono do serviço
+	servicos := router.Group("/servicos")
+	servicos.Use(authMiddleware.MiddlewareFunc())
+	{
+		servicos.PUT("/:id/recursos", middlewares.ServicoOwnershipRequired(), recursoHandler.DefinirConsumos)
+	}
+}
@@ -18,7 +18,7 @@ func SetupServicoRoutes(router *gin.Engine, servicoHandler *handlers.ServicoHand
 	{
 		// Rota para adicionar um novo serviço a um petshop
 		// Apenas o próprio petshop pode adicionar serviços
-		protected.POST("", middlewares.PetshopOwnershipFromParamRequired("petshopId"), servicoHandler.Create)
+		protected.POST("", middlewares.PetshopOwnershipFromParamRequired("petshopId"), middlewares.IdempotencyKey(), servicoHandler.Create)
 	}
 
 	// Rotas para manipular serviços individuais